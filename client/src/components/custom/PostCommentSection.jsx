@@ -85,7 +85,6 @@ const PostCommentSection = ({
       onNewComment(optimisticComment);
 
       const payload = {
-        user_id: user.id,
         post_id: postId,
         content: formData.content,
       };
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultAnalyticsDays = 30
+	maxAnalyticsDays     = 366
+)
+
+// @Summary		Get my analytics
+// @Description	Retrieve the daily views, likes and comments of the current user's posts, with totals. Days are UTC and both bounds are included.
+// @Description	The range defaults to the last 30 days and spans at most 366 days. Posts without activity in the range are left out.
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Param			from	query		string						false	"First day (YYYY-MM-DD)"
+// @Param			to		query		string						false	"Last day (YYYY-MM-DD), defaults to today"
+// @Success		200		{object}	service.AnalyticsResponse	"Successfully fetched analytics"
+// @Failure		400		{object}	error						"Invalid date range"
+// @Failure		401		{object}	error						"User not authenticated"
+// @Failure		500		{object}	error						"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/users/me/analytics [get]
+func (app *application) getAnalyticsHandler(w http.ResponseWriter, r *http.Request) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if param := r.URL.Query().Get("to"); param != "" {
+		parsed, err := time.Parse(time.DateOnly, param)
+		if err != nil {
+			app.badRequestResponse(w, r, fmt.Errorf("invalid to date, expected YYYY-MM-DD"))
+			return
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(defaultAnalyticsDays - 1))
+	if param := r.URL.Query().Get("from"); param != "" {
+		parsed, err := time.Parse(time.DateOnly, param)
+		if err != nil {
+			app.badRequestResponse(w, r, fmt.Errorf("invalid from date, expected YYYY-MM-DD"))
+			return
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		app.badRequestResponse(w, r, fmt.Errorf("from must not be after to"))
+		return
+	}
+
+	if to.Sub(from) >= maxAnalyticsDays*24*time.Hour {
+		app.badRequestResponse(w, r, fmt.Errorf("the range spans at most %d days", maxAnalyticsDays))
+		return
+	}
+
+	user := getUserFromCtx(r)
+
+	analytics, err := app.service.Views.GetAnalytics(r.Context(), user.ID, from, to)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, analytics); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
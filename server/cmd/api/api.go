@@ -20,6 +20,7 @@ import (
 	"github.com/ritchie-gr8/my-blog-app/docs"
 	"github.com/ritchie-gr8/my-blog-app/internal/auth"
 	"github.com/ritchie-gr8/my-blog-app/internal/mailer"
+	"github.com/ritchie-gr8/my-blog-app/internal/media"
 	"github.com/ritchie-gr8/my-blog-app/internal/ratelimiter"
 	"github.com/ritchie-gr8/my-blog-app/internal/store"
 	"github.com/ritchie-gr8/my-blog-app/internal/store/cache"
@@ -48,6 +49,54 @@ type config struct {
 	auth        authConfig
 	redis       redisConfig
 	rateLimiter ratelimiter.Config
+	search      searchConfig
+	feed        feedConfig
+	trash       trashConfig
+	media       mediaConfig
+	views       viewsConfig
+	locales     localesConfig
+	jobs        jobsConfig
+}
+
+type mediaConfig struct {
+	// storage is either "local" or "s3"
+	storage   string
+	localDir  string
+	publicURL string
+	limits    media.Limits
+	s3        media.S3Config
+}
+
+type jobsConfig struct {
+	trendingInterval       time.Duration
+	previewCleanupInterval time.Duration
+	trashPurgeInterval     time.Duration
+	viewFlushInterval      time.Duration
+	bulkOperationInterval  time.Duration
+}
+
+type viewsConfig struct {
+	// window is how long repeated views of a post by the same viewer are ignored
+	window            time.Duration
+	fingerprintSecret string
+}
+
+type trashConfig struct {
+	retention time.Duration
+}
+
+type localesConfig struct {
+	// supported is the comma separated list of locales posts can be written
+	// in, the first one is the default of the site
+	supported string
+}
+
+type searchConfig struct {
+	language string
+}
+
+type feedConfig struct {
+	cursorSecret string
 }
 
 type redisConfig struct {
@@ -109,8 +158,10 @@ func (app *application) mount() http.Handler {
 			"X-CSRF-Token",
 			"X-Requested-With",
 			"Origin",
+			"If-Match",
+			"If-None-Match",
 		},
-		ExposedHeaders:   []string{"Link"},
+		ExposedHeaders:   []string{"Link", "ETag"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -137,30 +188,174 @@ func (app *application) mount() http.Handler {
 			r.Route("/posts", func(r chi.Router) {
 				r.Route("/{postID}", func(r chi.Router) {
 					r.Use(app.postsContextMiddleware)
-					r.With(app.OptionalAuthMiddleware).Get("/", app.getPostHandler)
+					r.With(app.OptionalAuthMiddleware, app.cacheResponse(postCachePolicy)).Get("/", app.getPostHandler)
+					r.With(app.OptionalAuthMiddleware).Get("/related", app.getRelatedPostsHandler)
 
-					r.With(app.AuthTokenMiddleware).Delete("/", app.checkPostOwnership("admin", app.deletePostHandler))
-					r.With(app.AuthTokenMiddleware).Patch("/", app.checkPostOwnership("moderator", app.updatePostHandler))
+					r.With(app.AuthTokenMiddleware).Delete("/", app.checkPostOwnership("admin", app.checkPostPrecondition(app.deletePostHandler)))
+					r.With(app.AuthTokenMiddleware).Patch("/", app.checkPostEditor("moderator", app.checkPostPrecondition(app.updatePostHandler)))
+					r.With(app.OptionalAuthMiddleware).Get("/comments", app.getCommentsHandler)
 					r.With(app.AuthTokenMiddleware).Post("/comments", app.createCommentHandler)
+					r.Route("/comments/{commentID}", func(r chi.Router) {
+						r.Use(app.AuthTokenMiddleware, app.commentContextMiddleware)
+						r.Patch("/", app.checkCommentOwnership("moderator", app.updateCommentHandler))
+						r.Delete("/", app.checkCommentOwnership("moderator", app.deleteCommentHandler))
+						r.Get("/edits", app.checkCommentOwnership("moderator", app.getCommentEditsHandler))
+						r.Post("/like", app.likeCommentHandler)
+						r.Delete("/like", app.unlikeCommentHandler)
+					})
 					r.With(app.AuthTokenMiddleware).Post("/like", app.likePostHandler)
 					r.With(app.AuthTokenMiddleware).Delete("/like", app.unlikePostHandler)
+					r.With(app.AuthTokenMiddleware).Post("/bookmark", app.bookmarkPostHandler)
+					r.With(app.AuthTokenMiddleware).Delete("/bookmark", app.unbookmarkPostHandler)
+					r.With(app.AuthTokenMiddleware, app.checkRole("moderator")).Put("/pin", app.pinPostHandler)
+					r.With(app.AuthTokenMiddleware, app.checkRole("moderator")).Delete("/pin", app.unpinPostHandler)
+					r.With(app.AuthTokenMiddleware, app.checkRole("moderator")).Put("/feature", app.featurePostHandler)
+					r.With(app.AuthTokenMiddleware, app.checkRole("moderator")).Delete("/feature", app.unfeaturePostHandler)
+
+					r.Route("/reviews", func(r chi.Router) {
+						r.Use(app.AuthTokenMiddleware)
+						r.Get("/", app.checkPostAccess(store.CoAuthorViewer, "moderator", app.getReviewsHandler))
+						r.With(app.checkRole("moderator")).Post("/", app.createReviewHandler)
+					})
+
+					r.Route("/coauthors", func(r chi.Router) {
+						r.Use(app.AuthTokenMiddleware)
+						r.Get("/", app.checkPostAccess(store.CoAuthorViewer, "moderator", app.getCoAuthorsHandler))
+						r.Post("/", app.checkPostOwnership("moderator", app.inviteCoAuthorHandler))
+						r.Post("/accept", app.acceptCoAuthorInviteHandler)
+						r.Patch("/{userID}", app.checkPostOwnership("moderator", app.updateCoAuthorHandler))
+						r.Delete("/{userID}", app.removeCoAuthorHandler)
+					})
+
+					r.Route("/draft", func(r chi.Router) {
+						r.Use(app.AuthTokenMiddleware)
+						r.Get("/", app.checkPostEditor("moderator", app.getDraftHandler))
+						r.Put("/", app.checkPostEditor("moderator", app.saveDraftHandler))
+						r.Delete("/", app.checkPostEditor("moderator", app.discardDraftHandler))
+						r.Get("/preview", app.checkPostEditor("moderator", app.previewDraftHandler))
+						r.Post("/publish", app.checkPostEditor("moderator", app.checkPostPrecondition(app.publishDraftHandler)))
+					})
+
+					r.Route("/translations", func(r chi.Router) {
+						r.With(app.OptionalAuthMiddleware).Get("/", app.getTranslationsHandler)
+						r.With(app.AuthTokenMiddleware).Put("/{locale}", app.checkPostEditor("moderator", app.putTranslationHandler))
+						r.With(app.AuthTokenMiddleware).Delete("/{locale}", app.checkPostEditor("moderator", app.deleteTranslationHandler))
+					})
+
+					r.Route("/preview-tokens", func(r chi.Router) {
+						r.Use(app.AuthTokenMiddleware)
+						r.Get("/", app.checkPostOwnership("moderator", app.getPreviewTokensHandler))
+						r.Post("/", app.checkPostOwnership("moderator", app.createPreviewTokenHandler))
+						r.Delete("/{tokenID}", app.checkPostOwnership("moderator", app.revokePreviewTokenHandler))
+					})
 				})
 
+				r.With(app.cacheResponse(feedCachePolicy)).Get("/featured", app.getFeaturedPostsHandler)
 				r.With(app.AuthTokenMiddleware).Post("/", app.createPostHandler)
+				r.With(app.AuthTokenMiddleware).Post("/import", app.importPostsHandler)
+				r.With(app.OptionalAuthMiddleware).Get("/slug/{locale}/{slug}", app.getPostBySlugHandler)
+
+				r.Route("/export", func(r chi.Router) {
+					r.Use(app.AuthTokenMiddleware)
+					r.Get("/", app.exportPostsHandler)
+					r.With(app.checkRole("admin")).Get("/all", app.exportAllPostsHandler)
+				})
 			})
 
 			r.Route("/users", func(r chi.Router) {
 				r.Put("/activate/{token}", app.activeUserHandler)
+				r.With(app.AuthTokenMiddleware).Get("/me/analytics", app.getAnalyticsHandler)
+				r.With(app.AuthTokenMiddleware).Get("/me/bookmarks", app.getBookmarksHandler)
 
 				r.Route("/{userID}", func(r chi.Router) {
 					r.Use(app.AuthTokenMiddleware)
 					r.Get("/", app.getUserHandler)
 					r.Patch("/", app.updateUserHandler)
 					r.Patch("/password", app.resetPasswordHandler)
+					r.Post("/follow", app.followUserHandler)
+					r.Delete("/follow", app.unfollowUserHandler)
+					r.Get("/followers", app.getFollowersHandler)
+					r.Get("/following", app.getFollowingHandler)
+					r.Get("/follow-counts", app.getFollowCountsHandler)
+				})
+			})
+
+			r.Route("/feed", func(r chi.Router) {
+				r.With(app.OptionalAuthMiddleware, app.cacheResponse(feedCachePolicy)).Get("/", app.getFeedHandler)
+				r.With(app.AuthTokenMiddleware).Get("/following", app.getFollowingFeedHandler)
+			})
+			r.Get("/search", app.searchPostsHandler)
+			r.With(app.cacheResponse(archiveCachePolicy)).Get("/archive", app.getArchiveHandler)
+			r.Get("/preview/{token}", app.getPostPreviewHandler)
+
+			r.With(app.AuthTokenMiddleware, app.checkRole("moderator")).Get("/reviews/queue", app.getReviewQueueHandler)
+
+			r.Route("/reading-lists", func(r chi.Router) {
+				r.Use(app.AuthTokenMiddleware)
+				r.Get("/", app.getReadingListsHandler)
+				r.Post("/", app.createReadingListHandler)
+
+				r.Route("/{listID}", func(r chi.Router) {
+					r.Use(app.readingListContextMiddleware)
+					r.Get("/", app.getReadingListHandler)
+					r.Patch("/", app.updateReadingListHandler)
+					r.Delete("/", app.deleteReadingListHandler)
+					r.Post("/posts", app.addReadingListPostHandler)
+					r.Put("/posts/order", app.reorderReadingListHandler)
+					r.Delete("/posts/{postID}", app.removeReadingListPostHandler)
+				})
+			})
+
+			r.Route("/series", func(r chi.Router) {
+				r.With(app.AuthTokenMiddleware).Post("/", app.createSeriesHandler)
+
+				r.Route("/{seriesID}", func(r chi.Router) {
+					r.Use(app.seriesContextMiddleware)
+					r.With(app.OptionalAuthMiddleware).Get("/", app.getSeriesHandler)
+
+					r.Group(func(r chi.Router) {
+						r.Use(app.AuthTokenMiddleware)
+						r.Patch("/", app.checkSeriesOwnership("moderator", app.updateSeriesHandler))
+						r.Delete("/", app.checkSeriesOwnership("admin", app.deleteSeriesHandler))
+						r.Post("/posts", app.checkSeriesOwnership("moderator", app.addSeriesPostHandler))
+						r.Put("/posts/order", app.checkSeriesOwnership("moderator", app.reorderSeriesHandler))
+						r.Delete("/posts/{postID}", app.checkSeriesOwnership("moderator", app.removeSeriesPostHandler))
+					})
+				})
+			})
+
+			r.Route("/media", func(r chi.Router) {
+				r.With(app.AuthTokenMiddleware).Post("/", app.uploadMediaHandler)
+				r.Get("/{mediaID}", app.getMediaHandler)
+
+				if app.config.media.storage == "local" {
+					r.Handle("/files/*", app.mediaFilesHandler("/v1/media/files/"))
+				}
+			})
+
+			r.Route("/trash", func(r chi.Router) {
+				r.Use(app.AuthTokenMiddleware)
+				r.Get("/", app.getTrashHandler)
+				r.With(app.checkRole("admin")).Get("/all", app.getAllTrashHandler)
+
+				r.Route("/{postID}", func(r chi.Router) {
+					r.Use(app.trashedPostContextMiddleware)
+					r.Post("/restore", app.restorePostHandler)
+					r.Delete("/", app.purgePostHandler)
 				})
 			})
 
-			r.Get("/feed", app.getFeedHandler)
+			r.Route("/bulk-operations", func(r chi.Router) {
+				r.Use(app.AuthTokenMiddleware, app.checkRole("admin"))
+				r.Get("/", app.getBulkOperationsHandler)
+				r.Post("/", app.createBulkOperationHandler)
+
+				r.Route("/{operationID}", func(r chi.Router) {
+					r.Get("/", app.getBulkOperationHandler)
+					r.Get("/items", app.getBulkOperationItemsHandler)
+					r.Post("/resume", app.resumeBulkOperationHandler)
+				})
+			})
 
 			r.Route("/authentication", func(r chi.Router) {
 				r.Post("/user", app.registerUserHandler)
@@ -168,7 +363,7 @@ func (app *application) mount() http.Handler {
 			})
 
 			r.Route("/categories", func(r chi.Router) {
-				r.Get("/", app.getCategoriesHandler)
+				r.With(app.cacheResponse(categoriesCachePolicy)).Get("/", app.getCategoriesHandler)
 
 				r.With(app.AuthTokenMiddleware, app.checkRole("admin")).Route("/", func(r chi.Router) {
 					r.Get("/paginated", app.getPaginatedCategoriesHandler)
@@ -207,6 +402,11 @@ func (app *application) run(mux http.Handler) error {
 		IdleTimeout:  time.Minute,
 	}
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	app.startBackgroundJobs(jobsCtx)
+
 	shutdown := make(chan error)
 
 	go func() {
@@ -220,7 +420,14 @@ func (app *application) run(mux http.Handler) error {
 
 		app.logger.Infow("signal caught", "signal", s.String())
 
-		shutdown <- server.Shutdown(ctx)
+		stopJobs()
+
+		err := server.Shutdown(ctx)
+
+		// views buffered in memory would be lost otherwise
+		app.flushViews(context.Background())
+
+		shutdown <- err
 	}()
 
 	app.logger.Infow("server has started", "addr", app.config.addr, "env", app.config.env)
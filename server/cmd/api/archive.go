@@ -0,0 +1,45 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+// @Summary		Get the post archive
+// @Description	Count the published posts of each month, latest first, for archive navigation. Months are in UTC, from and to of each month
+// @Description	can be passed to the feed to list its posts.
+// @Tags			posts
+// @Accept			json
+// @Produce		json
+// @Param			author				query		string					false	"Username of the author to count posts of"
+// @Param			category			query		string					false	"Category to count posts of"
+// @Param			If-None-Match		header		string					false	"ETag of a cached response, answered with 304 when still current"
+// @Param			If-Modified-Since	header		string					false	"Last-Modified of a cached response, answered with 304 when unchanged since, ignored with If-None-Match"
+// @Success		200					{object}	service.ArchiveResponse	"Successfully fetched the archive"
+// @Success		304					"Not Modified"
+// @Failure		400					{object}	error	"Invalid request"
+// @Failure		500					{object}	error	"Internal server error"
+// @Router			/archive [get]
+func (app *application) getArchiveHandler(w http.ResponseWriter, r *http.Request) {
+	aq := store.ArchiveQuery{
+		Author:   r.URL.Query().Get("author"),
+		Category: r.URL.Query().Get("category"),
+	}
+
+	if err := Validate.Struct(aq); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	archive, err := app.service.Posts.GetArchive(r.Context(), aq)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	addSurrogateKeys(w, "archive")
+	if err := app.jsonResponse(w, http.StatusOK, archive); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/cursor"
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+// @Summary		Bookmark a post
+// @Description	Save a post for later for the current user
+// @Tags			bookmarks
+// @Accept			json
+// @Produce		json
+// @Param			postID	path		int		true	"Post ID"
+// @Success		201		{string}	string	"Bookmark added successfully"
+// @Success		200		{string}	string	"Post already bookmarked by user"
+// @Failure		400		{object}	error	"Invalid request"
+// @Failure		404		{object}	error	"Post not found"
+// @Failure		500		{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/bookmark [post]
+func (app *application) bookmarkPostHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+	user := getUserFromCtx(r)
+
+	if !app.canViewPost(user, post) {
+		app.notFoundResponse(w, r, store.ErrNotFound)
+		return
+	}
+
+	bookmarked, err := app.service.Bookmarks.HasUserBookmarked(r.Context(), post.ID, user.ID)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if bookmarked {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := app.service.Bookmarks.Bookmark(r.Context(), post.ID, user.ID); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// @Summary		Remove a bookmark
+// @Description	Remove a post from the bookmarks of the current user
+// @Tags			bookmarks
+// @Accept			json
+// @Produce		json
+// @Param			postID	path		int		true	"Post ID"
+// @Success		204		{string}	string	"Bookmark removed successfully"
+// @Failure		400		{object}	error	"Invalid request"
+// @Failure		404		{object}	error	"Post not found"
+// @Failure		500		{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/bookmark [delete]
+func (app *application) unbookmarkPostHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+	user := getUserFromCtx(r)
+
+	err := app.service.Bookmarks.Unbookmark(r.Context(), post.ID, user.ID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Get my bookmarks
+// @Description	Retrieve the posts the current user bookmarked, most recent bookmark first, using cursor pagination
+// @Tags			bookmarks
+// @Accept			json
+// @Produce		json
+// @Param			cursor	query		string						false	"Opaque cursor from a previous response, empty for the first page"
+// @Param			limit	query		int							false	"Number of posts to retrieve (default is 20, max is 50)"	minimum(1)	maximum(50)
+// @Success		200		{object}	service.BookmarksResponse	"Successfully fetched bookmarks"
+// @Failure		400		{object}	error						"Invalid request"
+// @Failure		401		{object}	error						"User not authenticated"
+// @Failure		500		{object}	error						"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/users/me/bookmarks [get]
+func (app *application) getBookmarksHandler(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed < 1 || parsed > 50 {
+			app.badRequestResponse(w, r, fmt.Errorf("limit must be between 1 and 50"))
+			return
+		}
+		limit = parsed
+	}
+
+	user := getUserFromCtx(r)
+
+	page, err := app.service.Bookmarks.GetBookmarks(r.Context(), user.ID, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, cursor.ErrInvalidCursor):
+			app.badRequestResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, page); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ritchie-gr8/my-blog-app/cmd/service"
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+var errInvalidBulkOperation = errors.New("invalid bulk operation")
+
+// bulkPostStatuses are the statuses a bulk operation can set on posts
+var bulkPostStatuses = []string{
+	store.PostStatusDraft,
+	store.PostStatusSubmitted,
+	store.PostStatusInReview,
+	store.PostStatusApproved,
+	store.PostStatusChangesRequested,
+	store.PostStatusPublished,
+}
+
+type BulkFilterPayload struct {
+	IDs        []int64    `json:"ids" validate:"omitempty,max=1000,dive,gt=0"`
+	AuthorID   int64      `json:"author_id" validate:"omitempty,gt=0"`
+	CategoryID int64      `json:"category_id" validate:"omitempty,gt=0"`
+	Status     string     `json:"status" validate:"omitempty,max=50"`
+	PostID     int64      `json:"post_id" validate:"omitempty,gt=0"`
+	From       *time.Time `json:"from"`
+	To         *time.Time `json:"to"`
+}
+
+type CreateBulkOperationPayload struct {
+	Target string `json:"target" validate:"required,oneof=posts comments"`
+	Action string `json:"action" validate:"required,oneof=delete set_status set_category reassign_author"`
+	// Value is the status, the category id or the author id the action sets
+	Value      string            `json:"value" validate:"max=50"`
+	Filter     BulkFilterPayload `json:"filter"`
+	DryRun     bool              `json:"dry_run"`
+	Background bool              `json:"background"`
+}
+
+// @Summary		Run a bulk operation
+// @Description	Delete, change the status, the category or the author of every post, or delete every comment, matching a filter. Small operations run in a single transaction, larger ones have to run in the background and can be resumed when they fail. A dry run only counts the matching rows. Deleting a comment deletes its replies too, they are logged with the operation items but not counted. Admin only
+// @Tags			bulk-operations
+// @Accept			json
+// @Produce		json
+// @Param			target		body		string						true	"posts or comments"
+// @Param			action		body		string						true	"delete, set_status, set_category or reassign_author, comments can only be deleted"
+// @Param			value		body		string						false	"Status, category ID or author ID to set"
+// @Param			filter		body		BulkFilterPayload			true	"Rows to change, ids, author_id, from and to apply to both targets, category_id and status to posts, post_id to comments"
+// @Param			dry_run		body		bool						false	"Only count the matching rows"
+// @Param			background	body		bool						false	"Process the operation in batches in the background"
+// @Success		200			{object}	store.BulkOperation			"Operation completed"
+// @Success		202			{object}	store.BulkOperation			"Operation queued"
+// @Success		200			{object}	service.BulkDryRunResponse	"Dry run"
+// @Failure		400			{object}	error						"Invalid request or too many rows for a single transaction"
+// @Failure		401			{object}	error						"User not authenticated"
+// @Failure		403			{object}	error						"Forbidden"
+// @Failure		500			{object}	error						"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/bulk-operations [post]
+func (app *application) createBulkOperationHandler(w http.ResponseWriter, r *http.Request) {
+	var payload CreateBulkOperationPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	user := getUserFromCtx(r)
+
+	op := &store.BulkOperation{
+		UserID:     &user.ID,
+		Target:     payload.Target,
+		Action:     payload.Action,
+		Background: payload.Background,
+		Filter: store.BulkFilter{
+			IDs:        payload.Filter.IDs,
+			AuthorID:   payload.Filter.AuthorID,
+			CategoryID: payload.Filter.CategoryID,
+			Status:     payload.Filter.Status,
+			PostID:     payload.Filter.PostID,
+			From:       payload.Filter.From,
+			To:         payload.Filter.To,
+		},
+	}
+
+	if err := app.validateBulkOperation(r, op, payload.Value); err != nil {
+		switch {
+		case errors.Is(err, errInvalidBulkOperation), errors.Is(err, store.ErrInvalidBulkAction):
+			app.badRequestResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if payload.DryRun {
+		dryRun, err := app.service.BulkOperations.DryRun(ctx, op)
+		if err != nil {
+			app.internalServerError(w, r, err)
+			return
+		}
+
+		if err := app.jsonResponse(w, http.StatusOK, dryRun); err != nil {
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.service.BulkOperations.Start(ctx, op); err != nil {
+		switch {
+		case errors.Is(err, service.ErrBulkTooLarge):
+			app.badRequestResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	app.logger.Infow("bulk operation started", "operationID", op.ID, "userID", user.ID,
+		"target", op.Target, "action", op.Action, "value", op.Value, "rows", op.Total)
+
+	status := http.StatusOK
+	if op.Background {
+		status = http.StatusAccepted
+	}
+
+	if err := app.jsonResponse(w, status, op); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// validateBulkOperation checks that the filter and the value suit the target
+// and the action, and sets the value in its stored form on op
+func (app *application) validateBulkOperation(r *http.Request, op *store.BulkOperation, value string) error {
+	f := op.Filter
+
+	if f.IsEmpty() {
+		return fmt.Errorf("%w: filter must not be empty", errInvalidBulkOperation)
+	}
+
+	if f.From != nil && f.To != nil && f.To.Before(*f.From) {
+		return fmt.Errorf("%w: to must not be before from", errInvalidBulkOperation)
+	}
+
+	if op.Target == store.BulkTargetComments {
+		if f.CategoryID != 0 || f.Status != "" {
+			return fmt.Errorf("%w: category_id and status only filter posts", errInvalidBulkOperation)
+		}
+		if op.Action != store.BulkActionDelete {
+			return fmt.Errorf("%w: comments can only be deleted", store.ErrInvalidBulkAction)
+		}
+		return nil
+	}
+
+	if f.PostID != 0 {
+		return fmt.Errorf("%w: post_id only filters comments", errInvalidBulkOperation)
+	}
+
+	switch op.Action {
+	case store.BulkActionDelete:
+		if value != "" {
+			return fmt.Errorf("%w: delete takes no value", errInvalidBulkOperation)
+		}
+	case store.BulkActionSetStatus:
+		for _, status := range bulkPostStatuses {
+			if strings.EqualFold(value, status) {
+				op.Value = status
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: value must be one of %s", errInvalidBulkOperation, strings.Join(bulkPostStatuses, ", "))
+	case store.BulkActionSetCategory:
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id <= 0 {
+			return fmt.Errorf("%w: value must be a category id", errInvalidBulkOperation)
+		}
+		if _, err := app.service.Categories.GetByID(r.Context(), id); err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return fmt.Errorf("%w: category %d not found", errInvalidBulkOperation, id)
+			}
+			return err
+		}
+		op.Value = strconv.FormatInt(id, 10)
+	case store.BulkActionReassignAuthor:
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id <= 0 {
+			return fmt.Errorf("%w: value must be a user id", errInvalidBulkOperation)
+		}
+		if _, err := app.service.Users.GetFromDB(r.Context(), id); err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return fmt.Errorf("%w: user %d not found", errInvalidBulkOperation, id)
+			}
+			return err
+		}
+		op.Value = strconv.FormatInt(id, 10)
+	}
+
+	return nil
+}
+
+// @Summary		Get bulk operations
+// @Description	Retrieve the bulk operations, most recent first, with who ran them. Admin only
+// @Tags			bulk-operations
+// @Accept			json
+// @Produce		json
+// @Param			page	query		int								false	"Page number"	default(1)
+// @Param			limit	query		int								false	"Limit results"	default(20)
+// @Success		200		{object}	service.BulkOperationsResponse	"Successfully fetched bulk operations"
+// @Failure		401		{object}	error							"User not authenticated"
+// @Failure		403		{object}	error							"Forbidden"
+// @Failure		500		{object}	error							"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/bulk-operations [get]
+func (app *application) getBulkOperationsHandler(w http.ResponseWriter, r *http.Request) {
+	page, limit := getPageParams(r, 20, 100)
+
+	operations, err := app.service.BulkOperations.List(r.Context(), page, limit)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, operations); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Get a bulk operation
+// @Description	Retrieve a bulk operation and its progress. Admin only
+// @Tags			bulk-operations
+// @Accept			json
+// @Produce		json
+// @Param			operationID	path		int					true	"Operation ID"
+// @Success		200			{object}	store.BulkOperation	"Successfully fetched bulk operation"
+// @Failure		400			{object}	error				"Invalid request"
+// @Failure		403			{object}	error				"Forbidden"
+// @Failure		404			{object}	error				"Operation not found"
+// @Failure		500			{object}	error				"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/bulk-operations/{operationID} [get]
+func (app *application) getBulkOperationHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "operationID"), 10, 64)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	op, err := app.service.BulkOperations.Get(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, op); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Get the rows changed by a bulk operation
+// @Description	Retrieve the rows a bulk operation changed along with the values they had before, including the replies deleted with the matched comments. Admin only
+// @Tags			bulk-operations
+// @Accept			json
+// @Produce		json
+// @Param			operationID	path		int									true	"Operation ID"
+// @Param			page		query		int									false	"Page number"	default(1)
+// @Param			limit		query		int									false	"Limit results"	default(50)
+// @Success		200			{object}	service.BulkOperationItemsResponse	"Successfully fetched changed rows"
+// @Failure		400			{object}	error								"Invalid request"
+// @Failure		403			{object}	error								"Forbidden"
+// @Failure		404			{object}	error								"Operation not found"
+// @Failure		500			{object}	error								"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/bulk-operations/{operationID}/items [get]
+func (app *application) getBulkOperationItemsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "operationID"), 10, 64)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+
+	if _, err := app.service.BulkOperations.Get(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	page, limit := getPageParams(r, 50, 500)
+
+	items, err := app.service.BulkOperations.GetItems(ctx, id, page, limit)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, items); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Resume a bulk operation
+// @Description	Queue a failed background operation again, it continues after the last processed row. Admin only
+// @Tags			bulk-operations
+// @Accept			json
+// @Produce		json
+// @Param			operationID	path		int					true	"Operation ID"
+// @Success		202			{object}	store.BulkOperation	"Operation queued"
+// @Failure		400			{object}	error				"Invalid request"
+// @Failure		403			{object}	error				"Forbidden"
+// @Failure		404			{object}	error				"No failed background operation with this ID"
+// @Failure		500			{object}	error				"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/bulk-operations/{operationID}/resume [post]
+func (app *application) resumeBulkOperationHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "operationID"), 10, 64)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := app.service.BulkOperations.Resume(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	op, err := app.service.BulkOperations.Get(ctx, id)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusAccepted, op); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
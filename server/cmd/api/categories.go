@@ -14,8 +14,12 @@ import (
 // @Tags			categories
 // @Accept			json
 // @Produce		json
-// @Success		200	{array}		store.Category	"Successfully fetched categories"
-// @Failure		500	{object}	error			"Internal server error, the server encountered a problem"
+// @Param			If-None-Match		header		string			false	"ETag of a cached response, answered with 304 when still current"
+// @Param			If-Modified-Since	header		string			false	"Last-Modified of a cached response, answered with 304 when unchanged since, ignored with If-None-Match"
+// @Success		200					{array}		store.Category	"Successfully fetched categories"
+// @Header			200					{string}	Surrogate-Key	"Keys to purge the response from a reverse proxy"
+// @Success		304					"Not Modified"
+// @Failure		500					{object}	error	"Internal server error, the server encountered a problem"
 // @Router			/categories [get]
 func (app *application) getCategoriesHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -26,6 +30,12 @@ func (app *application) getCategoriesHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	keys := []string{"categories"}
+	for _, category := range categories {
+		keys = append(keys, categorySurrogateKey(category.ID))
+	}
+	addSurrogateKeys(w, keys...)
+
 	if err := app.jsonResponse(w, http.StatusOK, categories); err != nil {
 		app.internalServerError(w, r, err)
 		return
@@ -75,16 +85,18 @@ func (app *application) getPaginatedCategoriesHandler(w http.ResponseWriter, r *
 // @Tags			categories
 // @Accept			json
 // @Produce		json
-// @Param			name	body		string			true	"Category Name"
-// @Success		201		{object}	store.Category	"Successfully created category"
-// @Failure		400		{object}	error			"Invalid request, the request data was incorrect or malformed"
-// @Failure		409		{object}	error			"Conflict: Category with this name already exists"
-// @Failure		500		{object}	error			"Internal server error, the server encountered a problem"
+// @Param			name			body		string			true	"Category Name"
+// @Param			requires_review	body		bool			false	"Whether posts of the category must be approved before they are published"
+// @Success		201				{object}	store.Category	"Successfully created category"
+// @Failure		400				{object}	error			"Invalid request, the request data was incorrect or malformed"
+// @Failure		409				{object}	error			"Conflict: Category with this name already exists"
+// @Failure		500				{object}	error			"Internal server error, the server encountered a problem"
 // @Security		ApiKeyAuth
 // @Router			/categories [post]
 func (app *application) createCategoryHandler(w http.ResponseWriter, r *http.Request) {
 	var payload struct {
-		Name string `json:"name" validate:"required,max=50"`
+		Name           string `json:"name" validate:"required,max=50"`
+		RequiresReview bool   `json:"requires_review"`
 	}
 
 	if err := readJSON(w, r, &payload); err != nil {
@@ -98,7 +110,8 @@ func (app *application) createCategoryHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	category := &store.Category{
-		Name: payload.Name,
+		Name:           payload.Name,
+		RequiresReview: payload.RequiresReview,
 	}
 
 	ctx := r.Context()
@@ -157,17 +170,18 @@ func (app *application) deleteCategoryHandler(w http.ResponseWriter, r *http.Req
 }
 
 // @Summary		Update a category
-// @Description	Update a category's name
+// @Description	Update a category's name and whether its posts require review
 // @Tags			categories
 // @Accept			json
 // @Produce		json
-// @Param			categoryID	path		int				true	"Category ID"
-// @Param			name		body		string			true	"New Category Name"
-// @Success		200			{object}	store.Category	"Successfully updated category"
-// @Failure		400			{object}	error			"Invalid request, the request data was incorrect or malformed"
-// @Failure		404			{object}	error			"Category not found"
-// @Failure		409			{object}	error			"Conflict: Category with this name already exists"
-// @Failure		500			{object}	error			"Internal server error, the server encountered a problem"
+// @Param			categoryID		path		int				true	"Category ID"
+// @Param			name			body		string			true	"New Category Name"
+// @Param			requires_review	body		bool			false	"Whether posts of the category must be approved before they are published"
+// @Success		200				{object}	store.Category	"Successfully updated category"
+// @Failure		400				{object}	error			"Invalid request, the request data was incorrect or malformed"
+// @Failure		404				{object}	error			"Category not found"
+// @Failure		409				{object}	error			"Conflict: Category with this name already exists"
+// @Failure		500				{object}	error			"Internal server error, the server encountered a problem"
 // @Security		ApiKeyAuth
 // @Router			/categories/{categoryID} [patch]
 func (app *application) updateCategoryHandler(w http.ResponseWriter, r *http.Request) {
@@ -180,7 +194,8 @@ func (app *application) updateCategoryHandler(w http.ResponseWriter, r *http.Req
 
 	// Parse and validate the request body
 	var payload struct {
-		Name string `json:"name" validate:"required,max=50"`
+		Name           string `json:"name" validate:"required,max=50"`
+		RequiresReview *bool  `json:"requires_review"`
 	}
 
 	if err := readJSON(w, r, &payload); err != nil {
@@ -194,9 +209,21 @@ func (app *application) updateCategoryHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	ctx := r.Context()
-	category := &store.Category{
-		ID:   id,
-		Name: payload.Name,
+
+	category, err := app.service.Categories.GetByID(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	category.Name = payload.Name
+	if payload.RequiresReview != nil {
+		category.RequiresReview = *payload.RequiresReview
 	}
 
 	if err := app.service.Categories.Update(ctx, category); err != nil {
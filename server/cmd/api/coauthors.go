@@ -0,0 +1,260 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type InviteCoAuthorPayload struct {
+	UserID     int64  `json:"user_id" validate:"required,min=1"`
+	Permission string `json:"permission" validate:"required,oneof=editor viewer"`
+}
+
+type UpdateCoAuthorPayload struct {
+	Permission string `json:"permission" validate:"required,oneof=editor viewer"`
+}
+
+// @Summary		Get the co-authors of a post
+// @Description	Retrieve the co-authors of a post, pending invitations included. Visible to the author, the co-authors and moderators
+// @Tags			coauthors
+// @Accept			json
+// @Produce		json
+// @Param			postID	path		int				true	"Post ID"
+// @Success		200		{array}		store.CoAuthor	"Successfully fetched co-authors"
+// @Failure		403		{object}	error			"Forbidden"
+// @Failure		404		{object}	error			"Post not found"
+// @Failure		500		{object}	error			"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/coauthors [get]
+func (app *application) getCoAuthorsHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+
+	coAuthors, err := app.service.CoAuthors.GetByPostID(r.Context(), post.ID)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, coAuthors); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Invite a co-author
+// @Description	Invite a user to co-author a post. Editors can update the post, viewers can read it while it is a draft, neither can delete it.
+// @Description	The invitation has no effect until the user accepts it.
+// @Tags			coauthors
+// @Accept			json
+// @Produce		json
+// @Param			postID		path		int				true	"Post ID"
+// @Param			user_id		body		int64			true	"User ID"
+// @Param			permission	body		string			true	"Permission"	Enums(editor, viewer)
+// @Success		201			{object}	store.CoAuthor	"Successfully invited co-author"
+// @Failure		400			{object}	error			"Invalid request"
+// @Failure		403			{object}	error			"Forbidden"
+// @Failure		404			{object}	error			"Post or user not found"
+// @Failure		409			{object}	error			"User already invited"
+// @Failure		500			{object}	error			"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/coauthors [post]
+func (app *application) inviteCoAuthorHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+	user := getUserFromCtx(r)
+
+	var payload InviteCoAuthorPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if payload.UserID == post.UserID {
+		app.badRequestResponse(w, r, fmt.Errorf("the author of a post cannot be its co-author"))
+		return
+	}
+
+	ctx := r.Context()
+
+	invitee, err := app.service.Users.Get(ctx, payload.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	coAuthor := &store.CoAuthor{
+		PostID:         post.ID,
+		UserID:         invitee.ID,
+		Permission:     payload.Permission,
+		Name:           invitee.Name,
+		Username:       invitee.Username,
+		ProfilePicture: invitee.ProfilePicture,
+		InvitedBy:      &user.ID,
+	}
+
+	if err := app.service.CoAuthors.Invite(ctx, coAuthor); err != nil {
+		switch {
+		case errors.Is(err, store.ErrUniqueViolation):
+			app.conflictResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	notification, err := app.service.Notifications.CreateCoAuthorInviteNotification(ctx, post.ID, invitee.ID, user.ID)
+	if err != nil {
+		app.logger.Warnw("failed to create notification", "error", err)
+	} else {
+		notification.Actor = &store.User{
+			ID:             user.ID,
+			Name:           user.Name,
+			Username:       user.Username,
+			ProfilePicture: user.ProfilePicture,
+		}
+		app.sseManager.SendToUser(invitee.ID, notification)
+	}
+
+	if err := app.jsonResponse(w, http.StatusCreated, coAuthor); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Accept a co-author invitation
+// @Description	Accept the pending invitation of the current user to co-author a post
+// @Tags			coauthors
+// @Accept			json
+// @Produce		json
+// @Param			postID	path		int		true	"Post ID"
+// @Success		204		{string}	string	"Invitation accepted"
+// @Failure		404		{object}	error	"Post or invitation not found"
+// @Failure		500		{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/coauthors/accept [post]
+func (app *application) acceptCoAuthorInviteHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+	user := getUserFromCtx(r)
+
+	if err := app.service.CoAuthors.Accept(r.Context(), post.ID, user.ID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Update a co-author
+// @Description	Change the permission of a co-author or of a pending invitation
+// @Tags			coauthors
+// @Accept			json
+// @Produce		json
+// @Param			postID		path		int		true	"Post ID"
+// @Param			userID		path		int		true	"Co-author user ID"
+// @Param			permission	body		string	true	"Permission"	Enums(editor, viewer)
+// @Success		204			{string}	string	"Co-author updated"
+// @Failure		400			{object}	error	"Invalid request"
+// @Failure		403			{object}	error	"Forbidden"
+// @Failure		404			{object}	error	"Co-author not found"
+// @Failure		500			{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/coauthors/{userID} [patch]
+func (app *application) updateCoAuthorHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+
+	userID, err := strconv.ParseInt(chi.URLParam(r, "userID"), 10, 64)
+	if err != nil || userID < 1 {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid user ID"))
+		return
+	}
+
+	var payload UpdateCoAuthorPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := app.service.CoAuthors.UpdatePermission(r.Context(), post.ID, userID, payload.Permission); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Remove a co-author
+// @Description	Remove a co-author or withdraw an invitation. Co-authors can also remove themselves, which declines a pending invitation
+// @Tags			coauthors
+// @Accept			json
+// @Produce		json
+// @Param			postID	path		int		true	"Post ID"
+// @Param			userID	path		int		true	"Co-author user ID"
+// @Success		204		{string}	string	"Co-author removed"
+// @Failure		400		{object}	error	"Invalid request"
+// @Failure		403		{object}	error	"Forbidden"
+// @Failure		404		{object}	error	"Co-author not found"
+// @Failure		500		{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/coauthors/{userID} [delete]
+func (app *application) removeCoAuthorHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+	user := getUserFromCtx(r)
+
+	userID, err := strconv.ParseInt(chi.URLParam(r, "userID"), 10, 64)
+	if err != nil || userID < 1 {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid user ID"))
+		return
+	}
+
+	if userID != user.ID && post.UserID != user.ID {
+		allowed, err := app.checkRolePrecedence(user, "moderator")
+		if err != nil {
+			app.internalServerError(w, r, err)
+			return
+		}
+
+		if !allowed {
+			app.forbiddenResponse(w, r)
+			return
+		}
+	}
+
+	if err := app.service.CoAuthors.Remove(r.Context(), post.ID, userID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ritchie-gr8/my-blog-app/internal/cursor"
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type commentKey string
+
+const commentCtx commentKey = "comment"
+
+// @Summary		Get the comments on a post
+// @Description	Retrieve a page of the comments on a post using cursor pagination, each with all its replies nested oldest first. limit counts the comments on the post only
+// @Tags			comments
+// @Accept			json
+// @Produce		json
+// @Param			postID	path		int							true	"Post ID"
+// @Param			sort	query		string						false	"newest (default), oldest or top, the comments with the most likes first"
+// @Param			cursor	query		string						false	"Opaque cursor from a previous response with the same sort, empty for the first page"
+// @Param			limit	query		int							false	"Number of comments to retrieve (default is 20, max is 50)"	minimum(1)	maximum(50)
+// @Success		200		{object}	service.CommentsResponse	"Successfully fetched comments"
+// @Failure		400		{object}	error						"Invalid request"
+// @Failure		404		{object}	error						"Post not found"
+// @Failure		500		{object}	error						"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/comments [get]
+func (app *application) getCommentsHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+	user := getUserFromCtx(r)
+	if !app.canViewPost(user, post) {
+		app.notFoundResponse(w, r, store.ErrNotFound)
+		return
+	}
+
+	var userID int64
+	if user != nil {
+		userID = user.ID
+	}
+
+	sort := r.URL.Query().Get("sort")
+	switch sort {
+	case "":
+		sort = store.CommentSortNewest
+	case store.CommentSortNewest, store.CommentSortOldest, store.CommentSortTop:
+	default:
+		app.badRequestResponse(w, r, fmt.Errorf("sort must be newest, oldest or top"))
+		return
+	}
+
+	limit := 20
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed < 1 || parsed > 50 {
+			app.badRequestResponse(w, r, fmt.Errorf("limit must be between 1 and 50"))
+			return
+		}
+		limit = parsed
+	}
+
+	page, err := app.service.Comments.GetPage(r.Context(), post.ID, userID, sort, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, cursor.ErrInvalidCursor):
+			app.badRequestResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, page); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+type UpdateCommentPayload struct {
+	Content string `json:"content" validate:"required,max=300"`
+}
+
+// @Summary		Update a comment
+// @Description	Edit the content of a comment. Authors can edit their own comments, moderators any comment. The previous content is kept in the edit history
+// @Tags			comments
+// @Accept			json
+// @Produce		json
+// @Param			postID		path		int				true	"Post ID"
+// @Param			commentID	path		int				true	"Comment ID"
+// @Param			content		body		string			true	"Comment Content"	maxLength(300)
+// @Success		200			{object}	store.Comment	"Successfully updated comment"
+// @Failure		400			{object}	error			"Invalid request"
+// @Failure		403			{object}	error			"Forbidden"
+// @Failure		404			{object}	error			"Post or comment not found"
+// @Failure		500			{object}	error			"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/comments/{commentID} [patch]
+func (app *application) updateCommentHandler(w http.ResponseWriter, r *http.Request) {
+	comment := getCommentFromCtx(r)
+
+	var payload UpdateCommentPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	user := getUserFromCtx(r)
+
+	if err := app.service.Comments.Update(r.Context(), comment, payload.Content, user.ID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, comment); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Delete a comment
+// @Description	Delete a comment. Authors can delete their own comments, moderators any comment. A comment with replies is replaced by a "[deleted]" tombstone so the thread is kept
+// @Tags			comments
+// @Accept			json
+// @Produce		json
+// @Param			postID		path			int	true	"Post ID"
+// @Param			commentID	path			int	true	"Comment ID"
+// @Success		204			"No Content"	"Successfully deleted comment"
+// @Failure		403			{object}		error	"Forbidden"
+// @Failure		404			{object}		error	"Post or comment not found"
+// @Failure		500			{object}		error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/comments/{commentID} [delete]
+func (app *application) deleteCommentHandler(w http.ResponseWriter, r *http.Request) {
+	comment := getCommentFromCtx(r)
+
+	tombstone, err := app.service.Comments.Delete(r.Context(), comment.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if user := getUserFromCtx(r); user.ID != comment.UserID {
+		app.logger.Infow("comment deleted by moderator", "commentID", comment.ID, "postID", comment.PostID,
+			"authorID", comment.UserID, "moderatorID", user.ID, "tombstone", tombstone)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Get the edit history of a comment
+// @Description	Retrieve the previous contents of a comment, oldest first. Only for its author and moderators
+// @Tags			comments
+// @Accept			json
+// @Produce		json
+// @Param			postID		path		int					true	"Post ID"
+// @Param			commentID	path		int					true	"Comment ID"
+// @Success		200			{array}		store.CommentEdit	"Successfully fetched edit history"
+// @Failure		403			{object}	error				"Forbidden"
+// @Failure		404			{object}	error				"Post or comment not found"
+// @Failure		500			{object}	error				"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/comments/{commentID}/edits [get]
+func (app *application) getCommentEditsHandler(w http.ResponseWriter, r *http.Request) {
+	comment := getCommentFromCtx(r)
+
+	edits, err := app.service.Comments.GetEdits(r.Context(), comment.ID)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, edits); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// commentContextMiddleware loads the comment of the route, which has to be
+// on the post of the route and not deleted
+func (app *application) commentContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(chi.URLParam(r, "commentID"), 10, 64)
+		if err != nil || id < 1 {
+			app.badRequestResponse(w, r, fmt.Errorf("invalid comment ID"))
+			return
+		}
+
+		post := getPostFromCtx(r)
+		if !app.canViewPost(getUserFromCtx(r), post) {
+			app.notFoundResponse(w, r, store.ErrNotFound)
+			return
+		}
+
+		ctx := r.Context()
+
+		comment, err := app.service.Comments.GetByID(ctx, id)
+		if err != nil {
+			switch {
+			case errors.Is(err, store.ErrNotFound):
+				app.notFoundResponse(w, r, err)
+			default:
+				app.internalServerError(w, r, err)
+			}
+			return
+		}
+
+		if comment.PostID != post.ID || comment.Deleted {
+			app.notFoundResponse(w, r, store.ErrNotFound)
+			return
+		}
+
+		ctx = context.WithValue(ctx, commentCtx, comment)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func getCommentFromCtx(r *http.Request) *store.Comment {
+	comment, _ := r.Context().Value(commentCtx).(*store.Comment)
+	return comment
+}
+
+// checkCommentOwnership lets through the comment author and users with at
+// least requiredRole, like checkPostOwnership does for posts
+func (app *application) checkCommentOwnership(requiredRole string, next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := getUserFromCtx(r)
+		comment := getCommentFromCtx(r)
+
+		if comment.UserID == user.ID {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		allowed, err := app.checkRolePrecedence(user, requiredRole)
+		if err != nil {
+			app.internalServerError(w, r, err)
+			return
+		}
+
+		if !allowed {
+			app.forbiddenResponse(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
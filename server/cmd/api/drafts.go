@@ -0,0 +1,379 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type SaveDraftPayload struct {
+	// Revision is the revision of the draft the changes are based on, 0 when
+	// there is no draft yet
+	Revision       int     `json:"revision" validate:"gte=0"`
+	Title          *string `json:"title" validate:"omitempty,max=100"`
+	Introduction   *string `json:"introduction" validate:"omitempty,max=120"`
+	Content        *string `json:"content" validate:"omitempty,max=1000"`
+	CategoryID     *int64  `json:"category_id" validate:"omitempty"`
+	ThumbnailImage *string `json:"thumbnail_image" validate:"omitempty"`
+	// ThumbnailMediaID takes precedence over ThumbnailImage
+	ThumbnailMediaID *int64    `json:"thumbnail_media_id" validate:"omitempty,min=1"`
+	Tags             *[]string `json:"tags" validate:"omitempty,max=10,dive,required,max=30"`
+}
+
+type PublishDraftPayload struct {
+	Revision int `json:"revision" validate:"required,min=1"`
+	// Force publishes the draft even when the post was updated since the
+	// draft was started, overwriting those changes
+	Force bool `json:"force"`
+}
+
+// @Summary		Get the draft of a post
+// @Description	Retrieve the working copy of a post, which is autosaved while the post is edited and only shown to readers once published
+// @Tags			drafts
+// @Accept			json
+// @Produce		json
+// @Param			postID	path		int				true	"Post ID"
+// @Success		200		{object}	store.PostDraft	"Successfully fetched draft"
+// @Failure		403		{object}	error			"Forbidden"
+// @Failure		404		{object}	error			"Post not found or it has no draft"
+// @Failure		500		{object}	error			"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/draft [get]
+func (app *application) getDraftHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+
+	draft, err := app.service.Drafts.Get(r.Context(), post.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, draft); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Autosave the draft of a post
+// @Description	Apply changes to the working copy of a post, starting it from the live post when there is none. The live post is left as it is.
+// @Description	Saves must send the revision they are based on and fail with 409 when someone else saved since. Saves that change nothing keep the revision, clients are expected to debounce them.
+// @Tags			drafts
+// @Accept			json
+// @Produce		json
+// @Param			postID				path		int				true	"Post ID"
+// @Param			revision			body		int				true	"Revision of the draft the changes are based on, 0 to start a draft"
+// @Param			title				body		string			false	"Post Title"		maxLength(100)
+// @Param			introduction		body		string			false	"Post Introduction"	maxLength(120)
+// @Param			content				body		string			false	"Post Content"		maxLength(1000)
+// @Param			category_id			body		int64			false	"Post Category ID"
+// @Param			thumbnail_image		body		string			false	"Thumbnail Image"
+// @Param			thumbnail_media_id	body		int64			false	"ID of an uploaded image to use as thumbnail, overrides thumbnail_image"
+// @Param			tags				body		[]string		false	"Post Tags, replaces the current tags"
+// @Success		200					{object}	store.PostDraft	"Successfully saved draft"
+// @Success		201					{object}	store.PostDraft	"Successfully started draft"
+// @Failure		400					{object}	error			"Invalid request"
+// @Failure		403					{object}	error			"Forbidden"
+// @Failure		404					{object}	error			"Post not found"
+// @Failure		409					{object}	error			"The draft was saved since the given revision, the current revision is returned"
+// @Failure		500					{object}	error			"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/draft [put]
+func (app *application) saveDraftHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+	user := getUserFromCtx(r)
+
+	var payload SaveDraftPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+
+	current, err := app.service.Drafts.Get(ctx, post.ID)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			app.internalServerError(w, r, err)
+			return
+		}
+		current = store.NewPostDraft(post)
+	}
+
+	if payload.Revision != current.Revision {
+		app.draftConflictResponse(w, r, current)
+		return
+	}
+
+	edited := *current
+	edited.UserID = &user.ID
+
+	if payload.Title != nil {
+		edited.Title = *payload.Title
+	}
+
+	if payload.Introduction != nil {
+		edited.Introduction = *payload.Introduction
+	}
+
+	if payload.Content != nil {
+		edited.Content = *payload.Content
+	}
+
+	if payload.CategoryID != nil {
+		edited.CategoryID = *payload.CategoryID
+	}
+
+	if payload.ThumbnailImage != nil {
+		edited.ThumbnailImage = *payload.ThumbnailImage
+		edited.ThumbnailMediaID = nil
+	}
+
+	if payload.Tags != nil {
+		edited.Tags = *payload.Tags
+	}
+
+	if payload.ThumbnailMediaID != nil {
+		thumbnail, err := app.resolveMedia(ctx, *payload.ThumbnailMediaID, user)
+		if err != nil {
+			app.resolveMediaErrorResponse(w, r, err)
+			return
+		}
+
+		edited.ThumbnailMediaID = &thumbnail.ID
+		edited.ThumbnailImage = app.service.Media.URL(thumbnail, "large")
+	}
+
+	if err := app.service.Drafts.Save(ctx, current, &edited); err != nil {
+		switch {
+		case errors.Is(err, store.ErrDraftConflict):
+			app.draftConflictResponse(w, r, nil)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	status := http.StatusOK
+	if current.Revision == 0 {
+		status = http.StatusCreated
+	}
+
+	if err := app.jsonResponse(w, status, edited); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Discard the draft of a post
+// @Description	Delete the working copy of a post, the live post is left as it is
+// @Tags			drafts
+// @Accept			json
+// @Produce		json
+// @Param			postID	path			int	true	"Post ID"
+// @Success		204		"No Content"	"Successfully discarded draft"
+// @Failure		403		{object}		error	"Forbidden"
+// @Failure		404		{object}		error	"Post not found or it has no draft"
+// @Failure		500		{object}		error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/draft [delete]
+func (app *application) discardDraftHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+
+	if err := app.service.Drafts.Discard(r.Context(), post.ID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Preview the draft of a post
+// @Description	Retrieve the post as it will read once its draft is published
+// @Tags			drafts
+// @Accept			json
+// @Produce		json
+// @Param			postID	path		int			true	"Post ID"
+// @Success		200		{object}	store.Post	"Successfully previewed draft"
+// @Failure		403		{object}	error		"Forbidden"
+// @Failure		404		{object}	error		"Post not found or it has no draft"
+// @Failure		500		{object}	error		"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/draft/preview [get]
+func (app *application) previewDraftHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := getUserFromCtx(r)
+
+	draft, err := app.service.Drafts.Get(ctx, getPostFromCtx(r).ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	post, err := app.service.Posts.Get(ctx, draft.PostID, user.ID)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if draft.CategoryID != 0 && draft.CategoryID != post.CategoryID {
+		category, err := app.service.Categories.GetByID(ctx, draft.CategoryID)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			app.internalServerError(w, r, err)
+			return
+		}
+
+		if category != nil {
+			post.Category = category.Name
+		}
+	}
+
+	draft.Apply(post)
+
+	if err := app.jsonResponse(w, http.StatusOK, post); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Publish the draft of a post
+// @Description	Copy the working copy of a post onto the live post and discard it, in a single transaction. The status of the post is kept,
+// @Description	except that approved posts, and published posts of categories that require review, go back to review when their author publishes changes.
+// @Tags			drafts
+// @Accept			json
+// @Produce		json
+// @Param			postID		path		int			true	"Post ID"
+// @Param			revision	body		int			true	"Revision of the draft that was reviewed"
+// @Param			force		body		bool		false	"Publish even when the post was updated since the draft was started"
+// @Param			If-Match	header		string		false	"ETag of the post, the publish fails if the post changed since"
+// @Success		200			{object}	store.Post	"Successfully published draft"
+// @Header			200			{string}	ETag		"Version of the updated post"
+// @Failure		400			{object}	error		"Invalid request"
+// @Failure		403			{object}	error		"Forbidden"
+// @Failure		404			{object}	error		"Post not found or it has no draft"
+// @Failure		409			{object}	error		"The draft was saved since the given revision or the post was updated since the draft was started"
+// @Failure		412			{object}	error		"The post changed since the If-Match version, the current version is returned"
+// @Failure		500			{object}	error		"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/draft/publish [post]
+func (app *application) publishDraftHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+	user := getUserFromCtx(r)
+	previousStatus := post.Status
+
+	var payload PublishDraftPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+
+	draft, err := app.service.Drafts.Get(ctx, post.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if payload.Revision != draft.Revision {
+		app.draftConflictResponse(w, r, draft)
+		return
+	}
+
+	if !payload.Force && draft.BaseVersion != post.Version {
+		app.conflictResponse(w, r, fmt.Errorf(
+			"the post was updated to version %d since the draft was started from version %d, publish with force to overwrite it",
+			post.Version, draft.BaseVersion,
+		))
+		return
+	}
+
+	draft.Apply(post)
+
+	// publishing a draft changes the content, like any other edit it sends
+	// approved posts and published posts needing review back to review
+	isModerator := app.canViewDrafts(user)
+	if err := app.service.Reviews.CheckEdit(ctx, post, previousStatus, true, isModerator); err != nil {
+		app.statusTransitionErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.service.Drafts.Publish(ctx, post, draft.Revision); err != nil {
+		switch {
+		case errors.Is(err, store.ErrDraftConflict):
+			app.draftConflictResponse(w, r, nil)
+		case errors.Is(err, store.ErrNotFound):
+			app.postVersionConflictResponse(w, r, post.ID, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.service.Reviews.RecordStatusChange(ctx, post.ID, user.ID, previousStatus, post.Status); err != nil {
+		app.logger.Warnw("failed to record review history", "postID", post.ID, "error", err)
+	}
+
+	w.Header().Set("ETag", postETag(post))
+	if err := app.jsonResponse(w, http.StatusOK, post); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// draftConflictResponse answers a save or publish based on an outdated
+// revision with the current one. A nil draft is loaded again, it lost a race
+// against a concurrent save.
+func (app *application) draftConflictResponse(w http.ResponseWriter, r *http.Request, draft *store.PostDraft) {
+	if draft == nil {
+		postID := getPostFromCtx(r).ID
+		current, err := app.service.Drafts.Get(r.Context(), postID)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			current = &store.PostDraft{PostID: postID}
+		case err != nil:
+			app.internalServerError(w, r, err)
+			return
+		}
+		draft = current
+	}
+
+	app.logger.Warnw("draft conflict", "method", r.Method, "path", r.URL.Path, "postID", draft.PostID, "revision", draft.Revision)
+
+	type envelope struct {
+		Error           string `json:"error"`
+		CurrentRevision int    `json:"current_revision"`
+	}
+
+	writeJSON(w, http.StatusConflict, &envelope{
+		Error:           store.ErrDraftConflict.Error(),
+		CurrentRevision: draft.Revision,
+	})
+}
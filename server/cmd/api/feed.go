@@ -1,25 +1,40 @@
 package main
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/ritchie-gr8/my-blog-app/internal/cursor"
 	"github.com/ritchie-gr8/my-blog-app/internal/store"
 )
 
 // @Summary		Get post feed
-// @Description	Retrieve a paginated feed of posts with optional filters for category, search and status
+// @Description	Retrieve a paginated feed of posts with optional filters for category, search, status, author and creation date.
+// @Description	Pass the cursor param (empty for the first page) to use keyset pagination instead of pages, then follow next_cursor and prev_cursor from the response.
+// @Description	With the default sort and no search, the first page starts with the pinned posts, flagged with pinned, they are left out of the other pages.
 // @Tags			posts
 // @Accept			json
 // @Produce		json
-// @Param			page		query		int						false	"Page number for pagination (default is 1)"				minimum(1)
-// @Param			limit		query		int						false	"Number of posts to retrieve (default is 6, max is 20)"	minimum(1)	maximum(20)
-// @Param			sort		query		string					false	"Sort order (default is 'desc', options are 'asc' or 'desc')"
-// @Param			category	query		string					false	"Category to filter posts by"
-// @Param			search		query		string					false	"Search term to filter posts by (max length is 100)"
-// @Param			status		query		string					false	"Status to filter posts by (default will fetch all status, options are 'published' or 'draft')"
-// @Success		200			{object}	service.FeedResponse	"Successfully retrieved the posts feed"
-// @Failure		400			{object}	error					"Invalid request, the request data was incorrect or malformed"
-// @Failure		500			{object}	error					"Internal server error, the server encountered a problem"
+// @Param			page				query		int						false	"Page number for pagination (default is 1)"				minimum(1)
+// @Param			limit				query		int						false	"Number of posts to retrieve (default is 6, max is 20)"	minimum(1)	maximum(20)
+// @Param			sort				query		string					false	"Sort order (default is 'desc', options are 'asc', 'desc', 'trending', 'top' or 'most_commented')"
+// @Param			window				query		string					false	"Time window for the 'top' sort (default is 'all', options are '24h', '7d', '30d', '365d' or 'all')"
+// @Param			category			query		string					false	"Category to filter posts by"
+// @Param			search				query		string					false	"Search term to filter posts by (max length is 100)"
+// @Param			author				query		string					false	"Username of the author to filter posts by"
+// @Param			lang				query		string					false	"Only posts written in or translated to this locale, shown in it"
+// @Param			from				query		string					false	"Only posts created since this YYYY-MM-DD date or RFC 3339 timestamp"
+// @Param			to					query		string					false	"Only posts created until this YYYY-MM-DD date (included) or RFC 3339 timestamp"
+// @Param			status				query		string					false	"Status to filter posts by (default will fetch all visible posts, options are 'published', 'draft', 'submitted', 'in review', 'approved' or 'changes requested'). Unpublished posts are only listed for their authors and moderators"
+// @Param			cursor				query		string					false	"Opaque cursor from a previous response, enables keyset pagination and ignores page"
+// @Param			include_total		query		bool					false	"Include the total number of posts in cursor mode (default is false)"
+// @Param			If-None-Match		header		string					false	"ETag of a cached response, answered with 304 when still current"
+// @Param			If-Modified-Since	header		string					false	"Last-Modified of a cached response, answered with 304 when unchanged since, ignored with If-None-Match"
+// @Success		200					{object}	service.FeedResponse	"Successfully retrieved the posts feed"
+// @Header			200					{string}	Surrogate-Key			"Keys to purge the response from a reverse proxy"
+// @Success		304					"Not Modified"
+// @Failure		400					{object}	error	"Invalid request, the request data was incorrect or malformed"
+// @Failure		500					{object}	error	"Internal server error, the server encountered a problem"
 // @Security		ApiKeyAuth
 // @Router			/feed [get]
 func (app *application) getFeedHandler(w http.ResponseWriter, r *http.Request) {
@@ -40,15 +55,139 @@ func (app *application) getFeedHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if fq.Locale != "" {
+		if fq.Locale, err = app.service.Translations.ParseLocale(fq.Locale); err != nil {
+			app.badRequestResponse(w, r, err)
+			return
+		}
+	}
+
+	if user := getUserFromCtx(r); user != nil {
+		fq.ViewerID = user.ID
+		fq.ViewerCanSeeDrafts = app.canViewDrafts(user)
+	}
+
 	ctx := r.Context()
 
 	feed, err := app.service.Posts.GetFeed(ctx, fq)
 	if err != nil {
+		switch {
+		case errors.Is(err, cursor.ErrInvalidCursor):
+			app.badRequestResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if fq.Locale != "" {
+		if err := app.service.Translations.LocalizeFeed(r.Context(), feed.Items, fq.Locale); err != nil {
+			app.internalServerError(w, r, err)
+			return
+		}
+	}
+
+	setFeedCacheHeaders(w, feed.Items)
+	if err := app.jsonResponse(w, http.StatusOK, feed); err != nil {
 		app.internalServerError(w, r, err)
+	}
+}
+
+// setFeedCacheHeaders tags a feed page with the posts and categories it
+// lists, it goes stale as soon as one of them changes
+func setFeedCacheHeaders(w http.ResponseWriter, items []store.FeedItem) {
+	keys := []string{"feed"}
+	seen := map[string]bool{}
+
+	for _, item := range items {
+		for _, key := range []string{postSurrogateKey(item.ID), categorySurrogateKey(item.CategoryID)} {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	addSurrogateKeys(w, keys...)
+}
+
+// @Summary		Get following feed
+// @Description	Retrieve the published posts of the authors the current user follows, using cursor pagination
+// @Tags			posts
+// @Accept			json
+// @Produce		json
+// @Param			cursor			query		string					false	"Opaque cursor from a previous response, empty for the first page"
+// @Param			limit			query		int						false	"Number of posts to retrieve (default is 6, max is 20)"	minimum(1)	maximum(20)
+// @Param			sort			query		string					false	"Sort order (default is 'desc', options are 'asc', 'desc', 'trending', 'top' or 'most_commented')"
+// @Param			window			query		string					false	"Time window for the 'top' sort (default is 'all', options are '24h', '7d', '30d', '365d' or 'all')"
+// @Param			category		query		string					false	"Category to filter posts by"
+// @Param			include_total	query		bool					false	"Include the total number of posts (default is false)"
+// @Param			lang			query		string					false	"Only posts written in or translated to this locale, shown in it"
+// @Param			from			query		string					false	"Only posts created since this YYYY-MM-DD date or RFC 3339 timestamp"
+// @Param			to				query		string					false	"Only posts created until this YYYY-MM-DD date (included) or RFC 3339 timestamp"
+// @Success		200				{object}	service.FeedResponse	"Successfully retrieved the following feed"
+// @Failure		400				{object}	error					"Invalid request, the request data was incorrect or malformed"
+// @Failure		401				{object}	error					"User not authenticated"
+// @Failure		500				{object}	error					"Internal server error, the server encountered a problem"
+// @Security		ApiKeyAuth
+// @Router			/feed/following [get]
+func (app *application) getFollowingFeedHandler(w http.ResponseWriter, r *http.Request) {
+	fq := store.PaginatedFeedQuery{
+		Limit: 6,
+		Page:  1,
+		Sort:  "desc",
+	}
+
+	fq, err := fq.Parse(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
 		return
 	}
 
+	if err := Validate.Struct(fq); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if fq.Locale != "" {
+		if fq.Locale, err = app.service.Translations.ParseLocale(fq.Locale); err != nil {
+			app.badRequestResponse(w, r, err)
+			return
+		}
+	}
+
+	user := getUserFromCtx(r)
+
+	feed, err := app.service.Posts.GetFollowingFeed(r.Context(), fq, user.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, cursor.ErrInvalidCursor):
+			app.badRequestResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if fq.Locale != "" {
+		if err := app.service.Translations.LocalizeFeed(r.Context(), feed.Items, fq.Locale); err != nil {
+			app.internalServerError(w, r, err)
+			return
+		}
+	}
+
 	if err := app.jsonResponse(w, http.StatusOK, feed); err != nil {
 		app.internalServerError(w, r, err)
 	}
 }
+
+// canViewDrafts reports whether user may see the drafts of every author
+func (app *application) canViewDrafts(user *store.User) bool {
+	allowed, err := app.checkRolePrecedence(user, "moderator")
+	if err != nil {
+		app.logger.Warnw("failed to check role", "error", err)
+		return false
+	}
+
+	return allowed
+}
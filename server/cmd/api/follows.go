@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ritchie-gr8/my-blog-app/cmd/service"
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+// @Summary		Follow a user
+// @Description	Follow an author to get their posts in the following feed
+// @Tags			follows
+// @Accept			json
+// @Produce		json
+// @Param			userID	path		int		true	"User ID"
+// @Success		201		{string}	string	"User followed successfully"
+// @Success		200		{string}	string	"User already followed"
+// @Failure		400		{object}	error	"Invalid request"
+// @Failure		404		{object}	error	"User not found"
+// @Failure		500		{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/users/{userID}/follow [post]
+func (app *application) followUserHandler(w http.ResponseWriter, r *http.Request) {
+	followeeID, err := strconv.ParseInt(chi.URLParam(r, "userID"), 10, 64)
+	if err != nil || followeeID < 1 {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid user ID"))
+		return
+	}
+
+	user := getUserFromCtx(r)
+	if user.ID == followeeID {
+		app.badRequestResponse(w, r, fmt.Errorf("users cannot follow themselves"))
+		return
+	}
+
+	ctx := r.Context()
+
+	if _, err := app.service.Users.Get(ctx, followeeID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	following, err := app.service.Follows.IsFollowing(ctx, user.ID, followeeID)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if following {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := app.service.Follows.Follow(ctx, user.ID, followeeID); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// @Summary		Unfollow a user
+// @Description	Stop following an author
+// @Tags			follows
+// @Accept			json
+// @Produce		json
+// @Param			userID	path		int		true	"User ID"
+// @Success		204		{string}	string	"User unfollowed successfully"
+// @Failure		400		{object}	error	"Invalid request"
+// @Failure		500		{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/users/{userID}/follow [delete]
+func (app *application) unfollowUserHandler(w http.ResponseWriter, r *http.Request) {
+	followeeID, err := strconv.ParseInt(chi.URLParam(r, "userID"), 10, 64)
+	if err != nil || followeeID < 1 {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid user ID"))
+		return
+	}
+
+	user := getUserFromCtx(r)
+
+	err = app.service.Follows.Unfollow(r.Context(), user.ID, followeeID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Get followers
+// @Description	Retrieve the users following a user, most recent first
+// @Tags			follows
+// @Accept			json
+// @Produce		json
+// @Param			userID	path		int							true	"User ID"
+// @Param			page	query		int							false	"Page number"	default(1)
+// @Param			limit	query		int							false	"Limit results"	default(20)
+// @Success		200		{object}	service.FollowListResponse	"Successfully fetched followers"
+// @Failure		400		{object}	error						"Invalid request"
+// @Failure		500		{object}	error						"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/users/{userID}/followers [get]
+func (app *application) getFollowersHandler(w http.ResponseWriter, r *http.Request) {
+	app.followListHandler(w, r, app.service.Follows.GetFollowers)
+}
+
+// @Summary		Get followed users
+// @Description	Retrieve the users a user follows, most recent first
+// @Tags			follows
+// @Accept			json
+// @Produce		json
+// @Param			userID	path		int							true	"User ID"
+// @Param			page	query		int							false	"Page number"	default(1)
+// @Param			limit	query		int							false	"Limit results"	default(20)
+// @Success		200		{object}	service.FollowListResponse	"Successfully fetched followed users"
+// @Failure		400		{object}	error						"Invalid request"
+// @Failure		500		{object}	error						"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/users/{userID}/following [get]
+func (app *application) getFollowingHandler(w http.ResponseWriter, r *http.Request) {
+	app.followListHandler(w, r, app.service.Follows.GetFollowing)
+}
+
+func (app *application) followListHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	list func(ctx context.Context, userID int64, page, limit int) (*service.FollowListResponse, error),
+) {
+	userID, err := strconv.ParseInt(chi.URLParam(r, "userID"), 10, 64)
+	if err != nil || userID < 1 {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid user ID"))
+		return
+	}
+
+	page, limit := getPageParams(r, 20, 100)
+
+	users, err := list(r.Context(), userID, page, limit)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, users); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Get follow counts
+// @Description	Retrieve the number of followers and followed users of a user
+// @Tags			follows
+// @Accept			json
+// @Produce		json
+// @Param			userID	path		int					true	"User ID"
+// @Success		200		{object}	store.FollowCounts	"Successfully fetched follow counts"
+// @Failure		400		{object}	error				"Invalid request"
+// @Failure		500		{object}	error				"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/users/{userID}/follow-counts [get]
+func (app *application) getFollowCountsHandler(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(chi.URLParam(r, "userID"), 10, 64)
+	if err != nil || userID < 1 {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid user ID"))
+		return
+	}
+
+	counts, err := app.service.Follows.GetCounts(r.Context(), userID)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, counts); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// notifyFollowersOfNewPost stores a notification for every follower of the
+// author and pushes it to the ones that are connected to the SSE stream
+func (app *application) notifyFollowersOfNewPost(ctx context.Context, post *store.Post, author *store.User) {
+	userIDs, err := app.service.Notifications.CreateNewPostNotification(ctx, post.ID, author.ID)
+	if err != nil {
+		app.logger.Warnw("failed to create notification", "error", err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		notification := &store.Notification{
+			UserID:    userID,
+			Type:      "new_post",
+			RelatedID: post.ID,
+			ActorID:   author.ID,
+			PostID:    post.ID,
+			Message:   fmt.Sprintf("%s published a new post", author.Name),
+			Actor: &store.User{
+				ID:             author.ID,
+				Name:           author.Name,
+				Username:       author.Username,
+				ProfilePicture: author.ProfilePicture,
+			},
+		}
+		app.sseManager.SendToUser(userID, notification)
+	}
+}
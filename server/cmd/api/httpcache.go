@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cachePolicy sets how long anonymous responses of a route may be reused.
+// Responses to authenticated users include per user fields (likes, drafts...)
+// so they are never shared, clients only revalidate them.
+type cachePolicy struct {
+	maxAge time.Duration
+	// sharedMaxAge applies to reverse proxies, they are purged through the
+	// surrogate keys so they can keep responses longer than browsers
+	sharedMaxAge time.Duration
+}
+
+var (
+	feedCachePolicy = cachePolicy{maxAge: 30 * time.Second, sharedMaxAge: time.Minute}
+	// views of posts served by a reverse proxy, or revalidated without
+	// running the handler, are not counted. The max ages keep the
+	// undercount small.
+	postCachePolicy       = cachePolicy{maxAge: time.Minute, sharedMaxAge: 2 * time.Minute}
+	categoriesCachePolicy = cachePolicy{maxAge: 5 * time.Minute, sharedMaxAge: time.Hour}
+	archiveCachePolicy    = cachePolicy{maxAge: 5 * time.Minute, sharedMaxAge: 15 * time.Minute}
+)
+
+// cacheResponse adds validators and caching headers to the successful GET
+// responses of a route and answers If-None-Match, or If-Modified-Since when
+// it is absent, with 304 Not Modified.
+//
+// The response is buffered to compute its ETag: a hash of the body, appended
+// to the ETag the handler set if any (see postETag). The ETag is remembered
+// with the content version it was built at, a request revalidating it while
+// the version is unchanged gets its 304 without running the handler, so
+// without querying the database beyond the version nor recording a view.
+// updated_at does not change with likes and comments while the body does, so
+// Last-Modified is the time the body was first served with its ETag instead.
+// It can be later than the actual change, never earlier.
+func (app *application) cacheResponse(policy cachePolicy) func(http.Handler) http.Handler {
+	validators := newValidatorCache(policy.maxAge)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := validatorKey(r)
+			version, err := app.service.ContentVersion.Get(r.Context())
+			if err != nil {
+				// responses are still built, only not revalidated early
+				app.logger.Warnw("failed to get content version", "error", err)
+			} else if header, ok := validators.get(key, version); ok && notModified(r, header) {
+				for name, values := range header {
+					w.Header()[name] = values
+				}
+				w.Header().Add("Vary", "Authorization")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			rec := &bufferedResponse{header: w.Header(), status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			if rec.status != http.StatusOK {
+				w.WriteHeader(rec.status)
+				w.Write(rec.body.Bytes())
+				return
+			}
+
+			etag := representationETag(w.Header().Get("ETag"), rec.body.Bytes())
+			w.Header().Set("ETag", etag)
+			lastModified := validators.lastModified(key, etag)
+			w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+			w.Header().Add("Vary", "Authorization")
+
+			if getUserFromCtx(r) != nil || r.Header.Get("Authorization") != "" {
+				w.Header().Set("Cache-Control", "private, no-cache")
+			} else {
+				w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d, s-maxage=%d",
+					int(policy.maxAge.Seconds()), int(policy.sharedMaxAge.Seconds())))
+			}
+
+			if err == nil {
+				// the version was read before the handler ran, a write in
+				// between only makes the next revalidation rebuild the
+				// response. A write bumping the version before it commits
+				// can leave the older body remembered under it, for at most
+				// the max age clients reuse it anyway.
+				validators.put(key, version, notModifiedHeader(w.Header()), lastModified)
+			}
+
+			if notModified(r, w.Header()) {
+				w.Header().Del("Content-Type")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			if r.Method != http.MethodHead {
+				w.Write(rec.body.Bytes())
+			}
+		})
+	}
+}
+
+// maxValidators bounds the number of responses a route remembers the
+// validators of, they are all dropped when it is reached
+const maxValidators = 10_000
+
+// validatorCache remembers, for each variant of the responses of a route,
+// the headers of a 304 answer and the content version they are current at.
+// They expire after ttl since some responses change without a write, like a
+// feed when a pin expires.
+type validatorCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]validatorEntry
+}
+
+type validatorEntry struct {
+	version      int64
+	header       http.Header
+	lastModified time.Time
+	expires      time.Time
+}
+
+func newValidatorCache(ttl time.Duration) *validatorCache {
+	return &validatorCache{ttl: ttl, entries: map[string]validatorEntry{}}
+}
+
+func (c *validatorCache) get(key string, version int64) (http.Header, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || entry.version != version || time.Now().After(entry.expires) {
+		return nil, false
+	}
+
+	return entry.header, true
+}
+
+// lastModified returns when the response of key changed to the body of etag:
+// when it was first built with it, or now
+func (c *validatorCache) lastModified(key, etag string) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// expired entries are kept until replaced, only their version is stale
+	if entry, ok := c.entries[key]; ok && entry.header.Get("ETag") == etag {
+		return entry.lastModified
+	}
+
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+func (c *validatorCache) put(key string, version int64, header http.Header, lastModified time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxValidators {
+		c.entries = map[string]validatorEntry{}
+	}
+
+	c.entries[key] = validatorEntry{
+		version:      version,
+		header:       header,
+		lastModified: lastModified,
+		expires:      time.Now().Add(c.ttl),
+	}
+}
+
+// validatorKey identifies the variant of a response: the URL, the languages
+// asked for and the user it was built for
+func validatorKey(r *http.Request) string {
+	var userID int64
+	if user := getUserFromCtx(r); user != nil {
+		userID = user.ID
+	}
+
+	return fmt.Sprintf("%s\x00%s\x00%d\x00%t", r.URL.RequestURI(), r.Header.Get("Accept-Language"), userID,
+		r.Header.Get("Authorization") != "")
+}
+
+// notModifiedHeader copies the headers a 304 answer repeats. The ones set
+// by other middlewares for the request, like CORS, are left out.
+func notModifiedHeader(header http.Header) http.Header {
+	copied := http.Header{}
+	for _, name := range []string{"ETag", "Last-Modified", "Cache-Control", "Content-Language", "Link", "Surrogate-Key"} {
+		for _, value := range header.Values(name) {
+			copied.Add(name, value)
+		}
+	}
+
+	return copied
+}
+
+// representationETag derives the ETag of a response body. Handlers may set a
+// strong ETag identifying the version of the resource, which is kept as a
+// prefix so If-Match keeps working on it.
+func representationETag(etag string, body []byte) string {
+	sum := sha256.Sum256(body)
+	hash := hex.EncodeToString(sum[:8])
+
+	if strings.HasPrefix(etag, `"`) && strings.HasSuffix(etag, `"`) && len(etag) > 1 {
+		return fmt.Sprintf(`"%s.%s"`, strings.Trim(etag, `"`), hash)
+	}
+
+	return fmt.Sprintf(`W/"%s"`, hash)
+}
+
+// notModified evaluates the conditions of a GET against the validators of
+// the current response: If-None-Match, or If-Modified-Since when it is absent
+func notModified(r *http.Request, header http.Header) bool {
+	if r.Header.Get("If-None-Match") != "" {
+		return ifNoneMatch(r, header.Get("ETag"))
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	modified, err := http.ParseTime(header.Get("Last-Modified"))
+	return err == nil && !modified.After(since)
+}
+
+// ifNoneMatch uses the weak comparison required for If-None-Match
+func ifNoneMatch(r *http.Request, etag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addSurrogateKeys tags a response so a reverse proxy can purge every cached
+// response that includes a given post or category
+func addSurrogateKeys(w http.ResponseWriter, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	if current := w.Header().Get("Surrogate-Key"); current != "" {
+		keys = append([]string{current}, keys...)
+	}
+
+	w.Header().Set("Surrogate-Key", strings.Join(keys, " "))
+}
+
+func postSurrogateKey(postID int64) string {
+	return fmt.Sprintf("post-%d", postID)
+}
+
+func categorySurrogateKey(categoryID int64) string {
+	return fmt.Sprintf("category-%d", categoryID)
+}
+
+func userSurrogateKey(userID int64) string {
+	return fmt.Sprintf("user-%d", userID)
+}
+
+// bufferedResponse holds a response until its ETag is known. The headers
+// are shared with the real response writer.
+type bufferedResponse struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	if b.wroteHeader {
+		return
+	}
+	b.status = status
+	b.wroteHeader = true
+}
+
+func (b *bufferedResponse) Write(data []byte) (int, error) {
+	b.wroteHeader = true
+	return b.body.Write(data)
+}
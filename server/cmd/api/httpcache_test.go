@@ -0,0 +1,144 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ritchie-gr8/my-blog-app/cmd/service"
+)
+
+func TestCacheResponse(t *testing.T) {
+	app := newTestApplication(t, config{})
+	policy := cachePolicy{maxAge: time.Minute, sharedMaxAge: time.Hour}
+
+	handler := app.cacheResponse(policy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"1-2"`)
+		addSurrogateKeys(w, postSurrogateKey(1))
+		w.Write([]byte(`{"data":{}}`))
+	}))
+
+	rr := executeRequest(httptest.NewRequest(http.MethodGet, "/", nil), handler)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	etag := rr.Header().Get("ETag")
+	if !strings.HasPrefix(etag, `"1-2.`) {
+		t.Errorf("expected the handler ETag to prefix the representation ETag; got %q", etag)
+	}
+
+	if cc := rr.Header().Get("Cache-Control"); cc != "public, max-age=60, s-maxage=3600" {
+		t.Errorf("unexpected Cache-Control %q", cc)
+	}
+
+	if keys := rr.Header().Get("Surrogate-Key"); keys != "post-1" {
+		t.Errorf("unexpected Surrogate-Key %q", keys)
+	}
+
+	t.Run("should answer 304 when the ETag matches", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("If-None-Match", `"other", W/`+etag)
+
+		rr := executeRequest(req, handler)
+		checkResponseCode(t, http.StatusNotModified, rr.Code)
+
+		if rr.Body.Len() != 0 {
+			t.Errorf("expected an empty body; got %q", rr.Body.String())
+		}
+	})
+
+	t.Run("should not share authenticated responses", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer token")
+
+		rr := executeRequest(req, handler)
+		if cc := rr.Header().Get("Cache-Control"); cc != "private, no-cache" {
+			t.Errorf("unexpected Cache-Control %q", cc)
+		}
+	})
+
+	t.Run("should match If-Match on the version only", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/", nil)
+		req.Header.Set("If-Match", etag)
+
+		if !ifMatch(req, `"1-2"`) {
+			t.Errorf("expected %q to match version 2", etag)
+		}
+		if ifMatch(req, `"1-3"`) {
+			t.Errorf("expected %q not to match version 3", etag)
+		}
+	})
+}
+
+func TestCacheResponseRevalidation(t *testing.T) {
+	app := newTestApplication(t, config{})
+	versions := &service.MockContentVersionService{Version: 1}
+	app.service.ContentVersion = versions
+
+	calls := 0
+	handler := app.cacheResponse(postCachePolicy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("ETag", `"1-2"`)
+		w.Write([]byte(`{"data":{}}`))
+	}))
+
+	rr := executeRequest(httptest.NewRequest(http.MethodGet, "/v1/posts/1", nil), handler)
+	checkResponseCode(t, http.StatusOK, rr.Code)
+	etag := rr.Header().Get("ETag")
+
+	revalidate := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/posts/1", nil)
+		req.Header.Set("If-None-Match", etag)
+		return executeRequest(req, handler)
+	}
+
+	t.Run("should answer 304 without running the handler", func(t *testing.T) {
+		rr := revalidate()
+		checkResponseCode(t, http.StatusNotModified, rr.Code)
+
+		if calls != 1 {
+			t.Errorf("expected the handler to run once; ran %d times", calls)
+		}
+		if got := rr.Header().Get("ETag"); got != etag {
+			t.Errorf("expected ETag %q; got %q", etag, got)
+		}
+	})
+
+	t.Run("should run the handler once the content changed", func(t *testing.T) {
+		versions.Version++
+
+		rr := revalidate()
+		checkResponseCode(t, http.StatusNotModified, rr.Code)
+
+		if calls != 2 {
+			t.Errorf("expected the handler to run again; ran %d times", calls)
+		}
+	})
+
+	t.Run("should keep Last-Modified while the body is unchanged", func(t *testing.T) {
+		lastModified := rr.Header().Get("Last-Modified")
+		if lastModified == "" {
+			t.Fatal("expected a Last-Modified header")
+		}
+
+		versions.Version++
+		req := httptest.NewRequest(http.MethodGet, "/v1/posts/1", nil)
+		rr := executeRequest(req, handler)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+
+		if got := rr.Header().Get("Last-Modified"); got != lastModified {
+			t.Errorf("expected Last-Modified %q; got %q", lastModified, got)
+		}
+	})
+
+	t.Run("should answer If-Modified-Since", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/posts/1", nil)
+		req.Header.Set("If-Modified-Since", rr.Header().Get("Last-Modified"))
+		checkResponseCode(t, http.StatusNotModified, executeRequest(req, handler).Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/v1/posts/1", nil)
+		req.Header.Set("If-Modified-Since", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+		checkResponseCode(t, http.StatusOK, executeRequest(req, handler).Code)
+	})
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/markdown"
+)
+
+const maxImportBytes = 20 << 20 // 20mb
+
+var errImportTooLarge = fmt.Errorf("archive is larger than %d bytes", maxImportBytes)
+
+// @Summary		Import posts from Markdown
+// @Description	Import a zip archive of Markdown files, as the "file" field of a multipart form. Each file starts with a YAML front matter
+// @Description	with title, intro, category, status, date, tags and optionally slug and author. Posts are matched on their slug, or their
+// @Description	path when there is none, so importing an archive again updates the posts instead of duplicating them.
+// @Description	Invalid files are reported and skipped. Admins can set keep_authors to assign the posts to the users named by their author.
+// @Tags			posts
+// @Accept			multipart/form-data
+// @Produce		json
+// @Param			file			formData	file			true	"Zip archive of Markdown files"
+// @Param			keep_authors	query		bool			false	"Assign the posts to the users named by their front matter author (admin only)"
+// @Success		200				{object}	markdown.Report	"Import report"
+// @Failure		400				{object}	error			"Invalid request or archive"
+// @Failure		401				{object}	error			"User not authenticated"
+// @Failure		403				{object}	error			"Only admins can keep authors"
+// @Failure		413				{object}	error			"Archive is too large"
+// @Failure		500				{object}	error			"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/import [post]
+func (app *application) importPostsHandler(w http.ResponseWriter, r *http.Request) {
+	user := getUserFromCtx(r)
+
+	keepAuthors := false
+	if param := r.URL.Query().Get("keep_authors"); param != "" {
+		parsed, err := strconv.ParseBool(param)
+		if err != nil {
+			app.badRequestResponse(w, r, fmt.Errorf("keep_authors must be a boolean"))
+			return
+		}
+		keepAuthors = parsed
+	}
+
+	if keepAuthors {
+		allowed, err := app.checkRolePrecedence(user, "admin")
+		if err != nil {
+			app.internalServerError(w, r, err)
+			return
+		}
+
+		if !allowed {
+			app.forbiddenResponse(w, r)
+			return
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes+multipartOverhead)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	var data []byte
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			app.importErrorResponse(w, r, err)
+			return
+		}
+
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		data, err = io.ReadAll(io.LimitReader(part, maxImportBytes+1))
+		part.Close()
+		if err != nil {
+			app.importErrorResponse(w, r, err)
+			return
+		}
+		break
+	}
+
+	if data == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing file field"))
+		return
+	}
+
+	if len(data) > maxImportBytes {
+		app.payloadTooLargeResponse(w, r, errImportTooLarge)
+		return
+	}
+
+	files, err := markdown.ReadArchive(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		switch {
+		case errors.Is(err, markdown.ErrArchiveTooLarge):
+			app.payloadTooLargeResponse(w, r, err)
+		default:
+			app.badRequestResponse(w, r, fmt.Errorf("invalid archive: %w", err))
+		}
+		return
+	}
+
+	report, err := app.service.Posts.Import(r.Context(), files, markdown.Options{
+		UserID:      user.ID,
+		Moderator:   app.canViewDrafts(user),
+		KeepAuthors: keepAuthors,
+	})
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, report); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+func (app *application) importErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.As(err, &maxBytesErr):
+		app.payloadTooLargeResponse(w, r, errImportTooLarge)
+	default:
+		app.badRequestResponse(w, r, err)
+	}
+}
+
+// @Summary		Export posts to Markdown
+// @Description	Download the posts of a user as a zip archive of Markdown files, in the format read by the import. Trashed posts are left out.
+// @Tags			posts
+// @Produce		application/zip
+// @Param			user_id	query		int		false	"User whose posts are exported, defaults to the current user. Only admins can export other users"
+// @Success		200		{file}		file	"Zip archive"
+// @Failure		400		{object}	error	"Invalid request"
+// @Failure		401		{object}	error	"User not authenticated"
+// @Failure		403		{object}	error	"Only admins can export other users"
+// @Failure		500		{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/export [get]
+func (app *application) exportPostsHandler(w http.ResponseWriter, r *http.Request) {
+	user := getUserFromCtx(r)
+
+	userID := user.ID
+	if param := r.URL.Query().Get("user_id"); param != "" {
+		parsed, err := strconv.ParseInt(param, 10, 64)
+		if err != nil || parsed < 1 {
+			app.badRequestResponse(w, r, fmt.Errorf("user_id must be a positive integer"))
+			return
+		}
+		userID = parsed
+	}
+
+	if userID != user.ID {
+		allowed, err := app.checkRolePrecedence(user, "admin")
+		if err != nil {
+			app.internalServerError(w, r, err)
+			return
+		}
+
+		if !allowed {
+			app.forbiddenResponse(w, r)
+			return
+		}
+	}
+
+	app.writeExport(w, r, userID, fmt.Sprintf("posts-%d", userID))
+}
+
+// @Summary		Export the whole blog to Markdown
+// @Description	Download the posts of every user as a zip archive of Markdown files, with a folder per author. Import it with keep_authors to restore it.
+// @Tags			posts
+// @Produce		application/zip
+// @Success		200	{file}		file	"Zip archive"
+// @Failure		401	{object}	error	"User not authenticated"
+// @Failure		403	{object}	error	"User is not an admin"
+// @Failure		500	{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/export/all [get]
+func (app *application) exportAllPostsHandler(w http.ResponseWriter, r *http.Request) {
+	app.writeExport(w, r, 0, "posts")
+}
+
+func (app *application) writeExport(w http.ResponseWriter, r *http.Request, userID int64, name string) {
+	files, err := app.service.Posts.Export(r.Context(), userID)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.zip", name, time.Now().UTC().Format("20060102"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	// the status is sent with the first bytes, failures can only be logged
+	if err := markdown.WriteArchive(w, files); err != nil {
+		app.logger.Errorw("failed to write export", "userID", userID, "error", err)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// startBackgroundJobs launches the periodic maintenance jobs. They stop when
+// ctx is cancelled, which happens when the server shuts down.
+func (app *application) startBackgroundJobs(ctx context.Context) {
+	app.runPeriodicJob(ctx, "refresh trending scores", app.config.jobs.trendingInterval, func(ctx context.Context) error {
+		updated, err := app.service.Posts.RefreshTrendingScores(ctx)
+		if err != nil {
+			return err
+		}
+
+		app.logger.Infow("trending scores refreshed", "posts", updated)
+		return nil
+	})
+
+	app.runPeriodicJob(ctx, "delete expired preview tokens", app.config.jobs.previewCleanupInterval, func(ctx context.Context) error {
+		deleted, err := app.service.Previews.DeleteExpiredTokens(ctx)
+		if err != nil {
+			return err
+		}
+
+		app.logger.Infow("expired preview tokens deleted", "tokens", deleted)
+		return nil
+	})
+
+	app.runPeriodicJob(ctx, "purge expired trash", app.config.jobs.trashPurgeInterval, func(ctx context.Context) error {
+		purged, err := app.service.Posts.PurgeExpiredTrash(ctx)
+		if err != nil {
+			return err
+		}
+
+		app.logger.Infow("expired trash purged", "posts", purged)
+		return nil
+	})
+
+	app.runPeriodicJob(ctx, "process bulk operations", app.config.jobs.bulkOperationInterval, func(ctx context.Context) error {
+		processed, err := app.service.BulkOperations.ProcessPending(ctx)
+		if err != nil {
+			return err
+		}
+
+		if processed > 0 {
+			app.logger.Infow("bulk operations processed", "rows", processed)
+		}
+		return nil
+	})
+
+	app.runPeriodicJob(ctx, "flush post views", app.config.jobs.viewFlushInterval, func(ctx context.Context) error {
+		app.flushViews(ctx)
+		return nil
+	})
+}
+
+// flushViews writes the buffered post views to the database. It also runs on
+// shutdown, after the last requests are served.
+func (app *application) flushViews(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	flushed, err := app.service.Views.Flush(ctx)
+	if err != nil {
+		app.logger.Errorw("failed to flush post views", "error", err.Error())
+		return
+	}
+
+	if flushed > 0 {
+		app.logger.Infow("post views flushed", "views", flushed)
+	}
+}
+
+// runPeriodicJob runs job every interval until ctx is done. A zero interval
+// disables the job.
+func (app *application) runPeriodicJob(ctx context.Context, name string, interval time.Duration, job func(context.Context) error) {
+	if interval <= 0 {
+		app.logger.Infow("background job disabled", "job", name)
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := job(ctx); err != nil {
+					app.logger.Errorw("background job failed", "job", name, "error", err.Error())
+				}
+			}
+		}
+	}()
+}
@@ -47,6 +47,11 @@ func (app *application) likePostHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if !app.canViewPost(user, post) {
+		app.notFoundResponse(w, r, store.ErrNotFound)
+		return
+	}
+
 	liked, err := app.service.PostLikes.HasUserLiked(r.Context(), postID, user.ID)
 	if err != nil {
 		app.internalServerError(w, r, err)
@@ -139,3 +144,74 @@ func (app *application) unlikePostHandler(w http.ResponseWriter, r *http.Request
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// @Summary		Like a comment
+// @Description	Add a like to a comment for the current user, liking it again is a no-op. The likes are the score the comments are sorted by with sort=top
+// @Tags			likes
+// @Accept			json
+// @Produce		json
+// @Param			postID		path		int		true	"Post ID"
+// @Param			commentID	path		int		true	"Comment ID"
+// @Success		201			{string}	string	"Like added successfully"
+// @Success		200			{string}	string	"Comment already liked by user"
+// @Failure		400			{object}	error	"Invalid request"
+// @Failure		404			{object}	error	"Post or comment not found"
+// @Failure		500			{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/comments/{commentID}/like [post]
+func (app *application) likeCommentHandler(w http.ResponseWriter, r *http.Request) {
+	comment := getCommentFromCtx(r)
+	user := getUserFromCtx(r)
+
+	liked, err := app.service.CommentLikes.LikeComment(r.Context(), comment.ID, user.ID)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if !liked {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	notification, err := app.service.Notifications.CreateCommentLikeNotification(r.Context(), comment, user.ID)
+	if err != nil {
+		app.logger.Warnw("failed to create notification", "error", err)
+	} else if notification != nil {
+		notification.Actor = &store.User{
+			ID:             user.ID,
+			Name:           user.Name,
+			Username:       user.Username,
+			ProfilePicture: user.ProfilePicture,
+		}
+		app.sseManager.SendToUser(notification.UserID, notification)
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// @Summary		Unlike a comment
+// @Description	Remove the like of the current user from a comment, unliking a comment that is not liked is a no-op
+// @Tags			likes
+// @Accept			json
+// @Produce		json
+// @Param			postID		path		int		true	"Post ID"
+// @Param			commentID	path		int		true	"Comment ID"
+// @Success		204			{string}	string	"Like removed successfully"
+// @Failure		400			{object}	error	"Invalid request"
+// @Failure		404			{object}	error	"Post or comment not found"
+// @Failure		500			{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/comments/{commentID}/like [delete]
+func (app *application) unlikeCommentHandler(w http.ResponseWriter, r *http.Request) {
+	comment := getCommentFromCtx(r)
+	user := getUserFromCtx(r)
+
+	err := app.service.CommentLikes.UnlikeComment(r.Context(), comment.ID, user.ID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"expvar"
 	"os"
 	"runtime"
@@ -14,6 +15,7 @@ import (
 	"github.com/ritchie-gr8/my-blog-app/internal/db"
 	"github.com/ritchie-gr8/my-blog-app/internal/env"
 	"github.com/ritchie-gr8/my-blog-app/internal/mailer"
+	"github.com/ritchie-gr8/my-blog-app/internal/media"
 	"github.com/ritchie-gr8/my-blog-app/internal/ratelimiter"
 	"github.com/ritchie-gr8/my-blog-app/internal/store"
 	"github.com/ritchie-gr8/my-blog-app/internal/store/cache"
@@ -97,6 +99,55 @@ func main() {
 			TimeFrame:            time.Second * 5,
 			Enabled:              env.GetBool("RATE_LIMITER_ENABLED", true),
 		},
+		search: searchConfig{
+			language: env.GetString("SEARCH_LANGUAGE", "english"),
+		},
+		media: mediaConfig{
+			storage:   env.GetString("MEDIA_STORAGE", "local"),
+			localDir:  env.GetString("MEDIA_LOCAL_DIR", "./uploads"),
+			publicURL: env.GetString("MEDIA_PUBLIC_URL", "http://localhost:8080/v1/media/files"),
+			limits: media.Limits{
+				MaxBytes:     int64(env.GetInt("MEDIA_MAX_UPLOAD_BYTES", 10<<20)), // 10mb
+				MaxDimension: env.GetInt("MEDIA_MAX_DIMENSION", 8000),
+				MaxPixels:    env.GetInt("MEDIA_MAX_PIXELS", 25_000_000),
+			},
+			s3: media.S3Config{
+				Endpoint:        env.GetString("S3_ENDPOINT", ""),
+				Region:          env.GetString("S3_REGION", "us-east-1"),
+				Bucket:          env.GetString("S3_BUCKET", ""),
+				AccessKeyID:     env.GetString("S3_ACCESS_KEY_ID", ""),
+				SecretAccessKey: env.GetString("S3_SECRET_ACCESS_KEY", ""),
+				PublicURL:       env.GetString("S3_PUBLIC_URL", ""),
+			},
+		},
+		views: viewsConfig{
+			window: env.GetDuration("VIEW_DEDUP_WINDOW", time.Minute*30),
+		},
+		locales: localesConfig{
+			supported: env.GetString("SUPPORTED_LOCALES", "en,th"),
+		},
+		trash: trashConfig{
+			retention: env.GetDuration("TRASH_RETENTION", time.Hour*24*30),
+		},
+		jobs: jobsConfig{
+			trendingInterval:       env.GetDuration("TRENDING_REFRESH_INTERVAL", time.Minute*10),
+			previewCleanupInterval: env.GetDuration("PREVIEW_TOKEN_CLEANUP_INTERVAL", time.Hour),
+			trashPurgeInterval:     env.GetDuration("TRASH_PURGE_INTERVAL", time.Hour),
+			viewFlushInterval:      env.GetDuration("VIEW_FLUSH_INTERVAL", time.Minute),
+			bulkOperationInterval:  env.GetDuration("BULK_OPERATION_INTERVAL", time.Second*10),
+		},
+	}
+
+	// the cursors and view fingerprints are signed with keys of their own,
+	// derived from the token secret when they are not set
+	cfg.feed.cursorSecret, err = dedicatedSecret("FEED_CURSOR_SECRET", cfg.auth.token.secret, "feed cursor")
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	cfg.views.fingerprintSecret, err = dedicatedSecret("VIEW_FINGERPRINT_SECRET", cfg.auth.token.secret, "view fingerprint")
+	if err != nil {
+		logger.Fatal(err)
 	}
 
 	// create db
@@ -131,11 +182,42 @@ func main() {
 	store := store.NewStorage(db)
 	cacheStore := cache.NewRedisStore(redisDB)
 
+	// posts are indexed with the search language when they are saved, an
+	// unknown one would fail every create and update
+	if err := store.Posts.CheckSearchLanguage(context.Background(), cfg.search.language); err != nil {
+		logger.Fatalw("invalid SEARCH_LANGUAGE", "language", cfg.search.language, "error", err)
+	}
+
 	mailer := mailer.NewSendgrid(cfg.mail.sendGrid.apiKey, cfg.mail.fromEmail)
 
 	emailConfig := service.NewEmailConfig(cfg.env, cfg.frontendURL, mailer)
 
-	service := service.NewService(store, cacheStore, logger, *emailConfig)
+	searchConfig := service.NewSearchConfig(cfg.search.language)
+
+	feedConfig := service.NewFeedConfig(cfg.feed.cursorSecret)
+
+	trashConfig := service.NewTrashConfig(cfg.trash.retention)
+
+	var blobs media.MediaStore
+	switch cfg.media.storage {
+	case "s3":
+		blobs = media.NewS3Store(cfg.media.s3)
+	case "local":
+		blobs, err = media.NewLocalStore(cfg.media.localDir, cfg.media.publicURL)
+		if err != nil {
+			logger.Panic(err)
+		}
+	default:
+		logger.Fatalw("unknown media storage", "storage", cfg.media.storage)
+	}
+	logger.Infow("media storage configured", "storage", cfg.media.storage)
+
+	mediaConfig := service.NewMediaConfig(blobs, cfg.media.limits)
+
+	viewsConfig := service.NewViewsConfig(cfg.views.window, cfg.views.fingerprintSecret)
+	localesConfig := service.NewLocalesConfig(cfg.locales.supported)
+
+	service := service.NewService(store, cacheStore, logger, *emailConfig, *searchConfig, *feedConfig, *trashConfig, *mediaConfig, *viewsConfig, *localesConfig)
 
 	jwtAuthenticator := auth.NewJWTAuthenticator(cfg.auth.token.secret, cfg.auth.token.issue, cfg.auth.token.issue)
 
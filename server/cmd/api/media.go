@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ritchie-gr8/my-blog-app/internal/media"
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+// multipartOverhead leaves room for the multipart boundaries and headers on
+// top of the file itself
+const multipartOverhead = 64 << 10
+
+var errMediaNotOwned = errors.New("media belongs to another user")
+
+// @Summary		Upload an image
+// @Description	Upload an image as the "file" field of a multipart form. JPEG, PNG, GIF and WebP are accepted, the type is detected from the content.
+// @Description	The image is re-encoded without its metadata (EXIF included) into original, large, medium and thumbnail variants.
+// @Tags			media
+// @Accept			multipart/form-data
+// @Produce		json
+// @Param			file	formData	file		true	"Image file"
+// @Success		201		{object}	store.Media	"Successfully uploaded image"
+// @Failure		400		{object}	error		"Invalid request or image dimensions out of bounds"
+// @Failure		401		{object}	error		"User not authenticated"
+// @Failure		413		{object}	error		"File is too large"
+// @Failure		415		{object}	error		"Unsupported file type"
+// @Failure		500		{object}	error		"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/media [post]
+func (app *application) uploadMediaHandler(w http.ResponseWriter, r *http.Request) {
+	maxBytes := app.config.media.limits.MaxBytes
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes+multipartOverhead)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	var data []byte
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			app.uploadErrorResponse(w, r, err)
+			return
+		}
+
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		data, err = io.ReadAll(io.LimitReader(part, maxBytes+1))
+		part.Close()
+		if err != nil {
+			app.uploadErrorResponse(w, r, err)
+			return
+		}
+		break
+	}
+
+	if data == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing file field"))
+		return
+	}
+
+	user := getUserFromCtx(r)
+
+	record, err := app.service.Media.Upload(r.Context(), user.ID, data)
+	if err != nil {
+		app.uploadErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusCreated, record); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+func (app *application) uploadErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.Is(err, media.ErrTooLarge), errors.As(err, &maxBytesErr):
+		app.payloadTooLargeResponse(w, r, media.ErrTooLarge)
+	case errors.Is(err, media.ErrUnsupportedType):
+		app.unsupportedMediaTypeResponse(w, r, err)
+	case errors.Is(err, media.ErrDimensions):
+		app.badRequestResponse(w, r, err)
+	default:
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Get an image
+// @Description	Retrieve an uploaded image and the URLs of its variants
+// @Tags			media
+// @Accept			json
+// @Produce		json
+// @Param			mediaID	path		int			true	"Media ID"
+// @Success		200		{object}	store.Media	"Successfully fetched image"
+// @Failure		400		{object}	error		"Invalid request"
+// @Failure		404		{object}	error		"Media not found"
+// @Failure		500		{object}	error		"Internal server error"
+// @Router			/media/{mediaID} [get]
+func (app *application) getMediaHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "mediaID"), 10, 64)
+	if err != nil || id < 1 {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid media ID"))
+		return
+	}
+
+	record, err := app.service.Media.Get(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, record); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// mediaFilesHandler serves the blobs of the local media storage. Keys are
+// never reused, so files are cached for good, and directories are not listed.
+func (app *application) mediaFilesHandler(prefix string) http.Handler {
+	files := http.StripPrefix(prefix, http.FileServer(http.Dir(app.config.media.localDir)))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		files.ServeHTTP(w, r)
+	})
+}
+
+// resolveMedia loads media referenced from a post or a profile. Users may
+// only reference their own uploads, moderators may reference any.
+func (app *application) resolveMedia(ctx context.Context, id int64, user *store.User) (*store.Media, error) {
+	record, err := app.service.Media.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if record.UserID == user.ID {
+		return record, nil
+	}
+
+	allowed, err := app.checkRolePrecedence(user, "moderator")
+	if err != nil {
+		return nil, err
+	}
+
+	if !allowed {
+		return nil, errMediaNotOwned
+	}
+
+	return record, nil
+}
+
+// resolveMediaErrorResponse writes the response for a failed resolveMedia
+func (app *application) resolveMediaErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		app.badRequestResponse(w, r, fmt.Errorf("media not found"))
+	case errors.Is(err, errMediaNotOwned):
+		app.forbiddenResponse(w, r)
+	default:
+		app.internalServerError(w, r, err)
+	}
+}
@@ -134,6 +134,17 @@ func (app *application) OptionalAuthMiddleware(next http.Handler) http.Handler {
 }
 
 func (app *application) checkPostOwnership(requiredRole string, next http.HandlerFunc) http.HandlerFunc {
+	return app.checkPostAccess("", requiredRole, next)
+}
+
+// checkPostEditor also lets through the co-authors with the editor permission
+func (app *application) checkPostEditor(requiredRole string, next http.HandlerFunc) http.HandlerFunc {
+	return app.checkPostAccess(store.CoAuthorEditor, requiredRole, next)
+}
+
+// checkPostAccess lets through the post author, the co-authors holding
+// permission when it is set, and users with at least requiredRole
+func (app *application) checkPostAccess(permission string, requiredRole string, next http.HandlerFunc) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		user := getUserFromCtx(r)
 		post := getPostFromCtx(r)
@@ -143,6 +154,11 @@ func (app *application) checkPostOwnership(requiredRole string, next http.Handle
 			return
 		}
 
+		if permission != "" && hasCoAuthorPermission(post.CoAuthorPermission(user.ID), permission) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		allowed, err := app.checkRolePrecedence(user, requiredRole)
 		if err != nil {
 			app.internalServerError(w, r, err)
@@ -158,6 +174,19 @@ func (app *application) checkPostOwnership(requiredRole string, next http.Handle
 	})
 }
 
+// hasCoAuthorPermission reports whether granted covers required, editors can
+// do everything viewers can
+func hasCoAuthorPermission(granted, required string) bool {
+	switch required {
+	case store.CoAuthorViewer:
+		return granted == store.CoAuthorViewer || granted == store.CoAuthorEditor
+	case store.CoAuthorEditor:
+		return granted == store.CoAuthorEditor
+	default:
+		return false
+	}
+}
+
 func (app *application) checkRole(requiredRole string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// getPageParams reads the page and limit query params of page based lists.
+// Missing or invalid values fall back to page 1 and defaultLimit.
+func getPageParams(r *http.Request, defaultLimit, maxLimit int) (page int, limit int) {
+	page = 1
+	limit = defaultLimit
+
+	if pageParam := r.URL.Query().Get("page"); pageParam != "" {
+		if parsedPage, err := strconv.Atoi(pageParam); err == nil && parsedPage > 0 {
+			page = parsedPage
+		}
+	}
+
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 && parsedLimit <= maxLimit {
+			limit = parsedLimit
+		}
+	}
+
+	return page, limit
+}
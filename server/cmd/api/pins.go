@@ -0,0 +1,230 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type PinPostPayload struct {
+	Scope     string     `json:"scope" validate:"omitempty,oneof=global category"`
+	Position  int        `json:"position" validate:"gte=0,lte=1000"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type FeaturePostPayload struct {
+	Position  int        `json:"position" validate:"gte=0,lte=1000"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// @Summary		Pin a post
+// @Description	List a post before the others on the first page of the newest first feed, or update its pin. Global pins show on the main feed and on the feed of the category of the post, category pins only on the latter
+// @Tags			posts
+// @Accept			json
+// @Produce		json
+// @Param			postID		path		int				true	"Post ID"
+// @Param			scope		body		string			false	"global (default) or category"
+// @Param			position	body		int				false	"Order of the pinned posts, lowest first"	minimum(0)	maximum(1000)
+// @Param			expires_at	body		string			false	"RFC 3339 timestamp the pin ends at, it never ends when omitted"
+// @Success		200			{object}	store.PostPin	"Successfully pinned post"
+// @Failure		400			{object}	error			"Invalid request or expiry in the past"
+// @Failure		403			{object}	error			"Forbidden"
+// @Failure		404			{object}	error			"Post not found"
+// @Failure		500			{object}	error			"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/pin [put]
+func (app *application) pinPostHandler(w http.ResponseWriter, r *http.Request) {
+	var payload PinPostPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if payload.ExpiresAt != nil && !payload.ExpiresAt.After(time.Now()) {
+		app.badRequestResponse(w, r, fmt.Errorf("expires_at must be in the future"))
+		return
+	}
+
+	if payload.Scope == "" {
+		payload.Scope = store.PinScopeGlobal
+	}
+
+	post := getPostFromCtx(r)
+	user := getUserFromCtx(r)
+
+	pin := &store.PostPin{
+		PostID:    post.ID,
+		Scope:     payload.Scope,
+		Position:  payload.Position,
+		ExpiresAt: payload.ExpiresAt,
+		UserID:    &user.ID,
+	}
+
+	if err := app.service.Pins.Pin(r.Context(), pin); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, pin); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Unpin a post
+// @Description	Put a pinned post back in its place in the feed
+// @Tags			posts
+// @Accept			json
+// @Produce		json
+// @Param			postID	path			int	true	"Post ID"
+// @Success		204		"No Content"	"Successfully unpinned post"
+// @Failure		403		{object}		error	"Forbidden"
+// @Failure		404		{object}		error	"Post not found or not pinned"
+// @Failure		500		{object}		error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/pin [delete]
+func (app *application) unpinPostHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+
+	if err := app.service.Pins.Unpin(r.Context(), post.ID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Feature a post
+// @Description	Add a post to the homepage carousel, or update its position. Only published posts are listed
+// @Tags			posts
+// @Accept			json
+// @Produce		json
+// @Param			postID		path		int					true	"Post ID"
+// @Param			position	body		int					false	"Order in the carousel, lowest first"	minimum(0)	maximum(1000)
+// @Param			expires_at	body		string				false	"RFC 3339 timestamp the post leaves the carousel at, it never does when omitted"
+// @Success		200			{object}	store.PostFeature	"Successfully featured post"
+// @Failure		400			{object}	error				"Invalid request or expiry in the past"
+// @Failure		403			{object}	error				"Forbidden"
+// @Failure		404			{object}	error				"Post not found"
+// @Failure		500			{object}	error				"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/feature [put]
+func (app *application) featurePostHandler(w http.ResponseWriter, r *http.Request) {
+	var payload FeaturePostPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if payload.ExpiresAt != nil && !payload.ExpiresAt.After(time.Now()) {
+		app.badRequestResponse(w, r, fmt.Errorf("expires_at must be in the future"))
+		return
+	}
+
+	post := getPostFromCtx(r)
+	user := getUserFromCtx(r)
+
+	feature := &store.PostFeature{
+		PostID:    post.ID,
+		Position:  payload.Position,
+		ExpiresAt: payload.ExpiresAt,
+		UserID:    &user.ID,
+	}
+
+	if err := app.service.Pins.Feature(r.Context(), feature); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, feature); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Unfeature a post
+// @Description	Remove a post from the homepage carousel
+// @Tags			posts
+// @Accept			json
+// @Produce		json
+// @Param			postID	path			int	true	"Post ID"
+// @Success		204		"No Content"	"Successfully unfeatured post"
+// @Failure		403		{object}		error	"Forbidden"
+// @Failure		404		{object}		error	"Post not found or not featured"
+// @Failure		500		{object}		error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/feature [delete]
+func (app *application) unfeaturePostHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+
+	if err := app.service.Pins.Unfeature(r.Context(), post.ID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Get featured posts
+// @Description	Retrieve the published posts of the homepage carousel, in carousel order
+// @Tags			posts
+// @Accept			json
+// @Produce		json
+// @Param			limit				query		int					false	"Number of posts to retrieve (default is 10, max is 20)"	minimum(1)	maximum(20)
+// @Param			If-None-Match		header		string				false	"ETag of a cached response, answered with 304 when still current"
+// @Param			If-Modified-Since	header		string				false	"Last-Modified of a cached response, answered with 304 when unchanged since, ignored with If-None-Match"
+// @Success		200					{array}		store.FeaturedPost	"Successfully fetched featured posts"
+// @Header			200					{string}	Surrogate-Key		"Keys to purge the response from a reverse proxy"
+// @Success		304					"Not Modified"
+// @Failure		400					{object}	error	"Invalid request"
+// @Failure		500					{object}	error	"Internal server error"
+// @Router			/posts/featured [get]
+func (app *application) getFeaturedPostsHandler(w http.ResponseWriter, r *http.Request) {
+	limit := 10
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed < 1 || parsed > 20 {
+			app.badRequestResponse(w, r, fmt.Errorf("limit must be between 1 and 20"))
+			return
+		}
+		limit = parsed
+	}
+
+	posts, err := app.service.Pins.GetFeatured(r.Context(), limit)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	items := make([]store.FeedItem, 0, len(posts))
+	for _, post := range posts {
+		items = append(items, post.FeedItem)
+	}
+
+	setFeedCacheHeaders(w, items)
+	if err := app.jsonResponse(w, http.StatusOK, posts); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
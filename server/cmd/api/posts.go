@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/ritchie-gr8/my-blog-app/cmd/service"
 	"github.com/ritchie-gr8/my-blog-app/internal/store"
 )
 
@@ -21,7 +24,12 @@ type CreatePostPayload struct {
 	Content        string `json:"content" validate:"required,max=1000"`
 	CategoryID     int64  `json:"category_id" validate:"required"`
 	ThumbnailImage string `json:"thumbnail_image" validate:"omitempty"`
-	Status         string `json:"status" validate:"omitempty"`
+	// ThumbnailMediaID takes precedence over ThumbnailImage
+	ThumbnailMediaID *int64   `json:"thumbnail_media_id" validate:"omitempty,min=1"`
+	Status           string   `json:"status" validate:"omitempty,oneof=Draft Submitted Published"`
+	Tags             []string `json:"tags" validate:"omitempty,max=10,dive,required,max=30"`
+	// Locale defaults to the locale of the site
+	Locale string `json:"locale" validate:"omitempty,max=35"`
 }
 
 type UpdatePostPayload struct {
@@ -30,13 +38,25 @@ type UpdatePostPayload struct {
 	Content        *string `json:"content" validate:"omitempty,max=1000"`
 	CategoryID     *int64  `json:"category_id" validate:"omitempty"`
 	ThumbnailImage *string `json:"thumbnail_image" validate:"omitempty"`
-	Status         *string `json:"status" validate:"omitempty"`
+	// ThumbnailMediaID takes precedence over ThumbnailImage
+	ThumbnailMediaID *int64    `json:"thumbnail_media_id" validate:"omitempty,min=1"`
+	Status           *string   `json:"status" validate:"omitempty,oneof=Draft Submitted Published"`
+	Tags             *[]string `json:"tags" validate:"omitempty,max=10,dive,required,max=30"`
+	Locale           *string   `json:"locale" validate:"omitempty,max=35"`
+}
+
+// changesContent reports whether the payload edits the post itself rather
+// than only its status
+func (p UpdatePostPayload) changesContent() bool {
+	return p.Title != nil || p.Introduction != nil || p.Content != nil || p.CategoryID != nil ||
+		p.ThumbnailImage != nil || p.ThumbnailMediaID != nil || p.Tags != nil || p.Locale != nil
 }
 
 type CreateCommentPayload struct {
 	PostID  int64  `json:"post_id" validate:"required"`
-	UserID  int64  `json:"user_id" validate:"required"`
 	Content string `json:"content" validate:"required,max=300"`
+	// ParentID is the comment replied to
+	ParentID *int64 `json:"parent_id" validate:"omitempty,gt=0"`
 }
 
 // @Summary		Create a post
@@ -44,15 +64,19 @@ type CreateCommentPayload struct {
 // @Tags			posts
 // @Accept			json
 // @Produce		json
-// @Param			title			body		string		true	"Post Title"		maxLength(100)
-// @Param			introduction	body		string		true	"Post Introduction"	maxLength(120)
-// @Param			content			body		string		true	"Post Content"		maxLength(1000)
-// @Param			category_id		body		int64		true	"Post Category ID"
-// @Param			thumbnail_image	body		string		false	"Thumbnail Image"
-// @Param			status			body		string		false	"Post Status"
-// @Success		201				{object}	store.Post	"Successfully created post"
-// @Failure		400				{object}	error		"Invalid request, the request data was incorrect or malformed"
-// @Failure		500				{object}	error		"Internal server error, the server encountered a problem"
+// @Param			title				body		string		true	"Post Title"		maxLength(100)
+// @Param			introduction		body		string		true	"Post Introduction"	maxLength(120)
+// @Param			content				body		string		true	"Post Content"		maxLength(1000)
+// @Param			category_id			body		int64		true	"Post Category ID"
+// @Param			thumbnail_image		body		string		false	"Thumbnail Image"
+// @Param			thumbnail_media_id	body		int64		false	"ID of an uploaded image to use as thumbnail, overrides thumbnail_image"
+// @Param			status				body		string		false	"Post Status, defaults to Published. Publishing in a category that requires review is rejected with 409, submit the post instead"	Enums(Draft, Submitted, Published)
+// @Param			tags				body		[]string	false	"Post Tags, at most 10 of at most 30 characters"
+// @Param			locale				body		string		false	"Language the post is written in, defaults to the locale of the site"
+// @Success		201					{object}	store.Post	"Successfully created post"
+// @Failure		400					{object}	error		"Invalid request, the request data was incorrect or malformed"
+// @Failure		409					{object}	error		"The category requires review before publishing"
+// @Failure		500					{object}	error		"Internal server error, the server encountered a problem"
 // @Security		ApiKeyAuth
 // @Router			/posts [post]
 func (app *application) createPostHandler(w http.ResponseWriter, r *http.Request) {
@@ -69,6 +93,10 @@ func (app *application) createPostHandler(w http.ResponseWriter, r *http.Request
 
 	user := getUserFromCtx(r)
 
+	if payload.Status == "" {
+		payload.Status = store.PostStatusPublished
+	}
+
 	post := &store.Post{
 		Title:          payload.Title,
 		Introduction:   payload.Introduction,
@@ -77,15 +105,55 @@ func (app *application) createPostHandler(w http.ResponseWriter, r *http.Request
 		UserID:         user.ID,
 		ThumbnailImage: payload.ThumbnailImage,
 		Status:         payload.Status,
+		Tags:           payload.Tags,
+	}
+
+	if post.Tags == nil {
+		post.Tags = []string{}
+	}
+
+	post.Locale = app.service.Translations.DefaultLocale()
+	if payload.Locale != "" {
+		locale, err := app.service.Translations.ParseLocale(payload.Locale)
+		if err != nil {
+			app.badRequestResponse(w, r, err)
+			return
+		}
+		post.Locale = locale
 	}
 
 	ctx := r.Context()
 
+	err := app.service.Reviews.CheckEdit(ctx, post, store.PostStatusDraft, true, app.canViewDrafts(user))
+	if err != nil {
+		app.statusTransitionErrorResponse(w, r, err)
+		return
+	}
+
+	if payload.ThumbnailMediaID != nil {
+		thumbnail, err := app.resolveMedia(ctx, *payload.ThumbnailMediaID, user)
+		if err != nil {
+			app.resolveMediaErrorResponse(w, r, err)
+			return
+		}
+
+		post.ThumbnailMediaID = &thumbnail.ID
+		post.ThumbnailImage = app.service.Media.URL(thumbnail, "large")
+	}
+
 	if err := app.service.Posts.Create(ctx, post); err != nil {
 		app.internalServerError(w, r, err)
 		return
 	}
 
+	if err := app.service.Reviews.RecordStatusChange(ctx, post.ID, user.ID, store.PostStatusDraft, post.Status); err != nil {
+		app.logger.Warnw("failed to record review history", "postID", post.ID, "error", err)
+	}
+
+	if strings.EqualFold(post.Status, store.PostStatusPublished) {
+		app.notifyFollowersOfNewPost(ctx, post, user)
+	}
+
 	if err := app.jsonResponse(w, http.StatusCreated, post); err != nil {
 		app.internalServerError(w, r, err)
 		return
@@ -93,15 +161,27 @@ func (app *application) createPostHandler(w http.ResponseWriter, r *http.Request
 }
 
 // @Summary		Get a post
-// @Description	Retrieve a post along with its comments
+// @Description	Retrieve a post along with its comments and, when it is part of a series, its place in the series.
+// @Description	The post is shown in the translation that best matches lang or Accept-Language, falling back to the locale of the site and then to the language it was written in.
+// @Description	All the comments are embedded unless comments_limit is set, then only their first page is, newest first, and comments_next_cursor continues it on /posts/{postID}/comments.
 // @Tags			posts
 // @Accept			json
 // @Produce		json
-// @Param			postID	path		int			true	"Post ID"
-// @Success		200		{object}	store.Post	"Successfully fetched post"
-// @Failure		400		{object}	error		"Invalid request, the request data was incorrect or malformed"
-// @Failure		404		{object}	error		"Post not found"
-// @Failure		500		{object}	error		"Internal server error, the server encountered a problem"
+// @Param			postID				path		int					true	"Post ID"
+// @Param			lang				query		string				false	"Preferred language, overrides Accept-Language"
+// @Param			comments_limit		query		int					false	"Embed only the first page of comments, with this many comments on the post"	minimum(1)	maximum(50)
+// @Param			Accept-Language		header		string				false	"Preferred languages"
+// @Param			If-None-Match		header		string				false	"ETag of a cached response, answered with 304 when still current"
+// @Param			If-Modified-Since	header		string				false	"Last-Modified of a cached response, answered with 304 when unchanged since, ignored with If-None-Match"
+// @Success		200					{object}	store.Post			"Successfully fetched post"
+// @Header			200					{string}	ETag				"Version of the post, to send as If-Match when editing it"
+// @Header			200					{string}	Content-Language	"Language the post is shown in"
+// @Header			200					{string}	Link				"Alternate languages of the post, with their hreflang"
+// @Header			200					{string}	Surrogate-Key		"Keys to purge the response from a reverse proxy"
+// @Success		304					"Not Modified"
+// @Failure		400					{object}	error	"Invalid request, the request data was incorrect or malformed"
+// @Failure		404					{object}	error	"Post not found"
+// @Failure		500					{object}	error	"Internal server error, the server encountered a problem"
 // @Security		ApiKeyAuth
 // @Router			/posts/{postID} [get]
 func (app *application) getPostHandler(w http.ResponseWriter, r *http.Request) {
@@ -113,36 +193,110 @@ func (app *application) getPostHandler(w http.ResponseWriter, r *http.Request) {
 		userID = user.ID
 	}
 
-	post, err := app.service.Posts.Get(r.Context(), post.ID, userID)
+	if !app.canViewPost(user, post) {
+		app.notFoundResponse(w, r, store.ErrNotFound)
+		return
+	}
+
+	preferred, err := preferredLanguages(r)
 	if err != nil {
-		app.internalServerError(w, r, err)
+		app.badRequestResponse(w, r, err)
 		return
 	}
 
-	comments, err := app.service.Comments.GetByPostID(r.Context(), post.ID)
+	commentsLimit := 0
+	if limitParam := r.URL.Query().Get("comments_limit"); limitParam != "" {
+		commentsLimit, err = strconv.Atoi(limitParam)
+		if err != nil || commentsLimit < 1 || commentsLimit > 50 {
+			app.badRequestResponse(w, r, fmt.Errorf("comments_limit must be between 1 and 50"))
+			return
+		}
+	}
+
+	post, err = app.service.Posts.Get(r.Context(), post.ID, userID)
 	if err != nil {
 		app.internalServerError(w, r, err)
 		return
 	}
 
-	post.Comments = comments
+	if err := app.service.Translations.Localize(r.Context(), post, preferred); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	app.recordView(r, post, user)
+
+	if commentsLimit > 0 {
+		page, err := app.service.Comments.GetPage(r.Context(), post.ID, userID, store.CommentSortNewest, "", commentsLimit)
+		if err != nil {
+			app.internalServerError(w, r, err)
+			return
+		}
 
+		post.Comments = page.Items
+		post.CommentsNextCursor = page.NextCursor
+	} else {
+		comments, err := app.service.Comments.GetByPostID(r.Context(), post.ID, userID)
+		if err != nil {
+			app.internalServerError(w, r, err)
+			return
+		}
+
+		post.Comments = comments
+	}
+
+	includeDrafts := user != nil && (user.ID == post.UserID || app.canViewDrafts(user))
+	post.Series, err = app.service.Series.GetNavigation(r.Context(), post.ID, includeDrafts)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", postETag(post))
+	setLanguageHeaders(w, post)
+	addSurrogateKeys(w, postSurrogateKey(post.ID), categorySurrogateKey(post.CategoryID), userSurrogateKey(post.UserID))
 	if err := app.jsonResponse(w, http.StatusOK, post); err != nil {
 		app.internalServerError(w, r, err)
 		return
 	}
 }
 
+// recordView counts a view of a published post. Authors reading their own
+// posts are not counted, anonymous readers are told apart by a fingerprint.
+func (app *application) recordView(r *http.Request, post *store.Post, user *store.User) {
+	if !strings.EqualFold(post.Status, store.PostStatusPublished) {
+		return
+	}
+
+	var viewer string
+	if user != nil {
+		if user.ID == post.UserID {
+			return
+		}
+		viewer = app.service.Views.UserViewer(user.ID)
+	} else {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+		viewer = app.service.Views.ViewerFingerprint(ip, r.UserAgent())
+	}
+
+	app.service.Views.Record(r.Context(), post.ID, viewer)
+}
+
 // @Summary		Delete a post
-// @Description	Delete a post by its ID
+// @Description	Move a post to the trash. It can be restored until it is purged after the retention period
 // @Tags			posts
 // @Accept			json
 // @Produce		json
-// @Param			postID	path			int	true	"Post ID"
-// @Success		204		"No Content"	"Successfully deleted post, no content returned"
-// @Failure		400		{object}		error	"Invalid request, the request data was incorrect or malformed"
-// @Failure		404		{object}		error	"Post not found"
-// @Failure		500		{object}		error	"Internal server error, the server encountered a problem"
+// @Param			postID		path			int		true	"Post ID"
+// @Param			If-Match	header			string	false	"ETag of the post, the delete fails if the post changed since"
+// @Success		204			"No Content"	"Successfully deleted post, no content returned"
+// @Failure		400			{object}		error	"Invalid request, the request data was incorrect or malformed"
+// @Failure		404			{object}		error	"Post not found"
+// @Failure		412			{object}		error	"The post changed since the If-Match version, the current version is returned"
+// @Failure		500			{object}		error	"Internal server error, the server encountered a problem"
 // @Security		ApiKeyAuth
 // @Router			/posts/{postID} [delete]
 func (app *application) deletePostHandler(w http.ResponseWriter, r *http.Request) {
@@ -155,8 +309,17 @@ func (app *application) deletePostHandler(w http.ResponseWriter, r *http.Request
 
 	ctx := r.Context()
 
-	if err := app.service.Posts.Delete(ctx, id); err != nil {
+	// checkPostPrecondition already matched the version, it only has to
+	// still be current
+	version := 0
+	if hasPrecondition(r) {
+		version = getPostFromCtx(r).Version
+	}
+
+	if err := app.service.Posts.Delete(ctx, id, version); err != nil {
 		switch {
+		case errors.Is(err, store.ErrNotFound) && version != 0:
+			app.postVersionConflictResponse(w, r, id, err)
 		case errors.Is(err, store.ErrNotFound):
 			app.notFoundResponse(w, r, err)
 		default:
@@ -173,22 +336,32 @@ func (app *application) deletePostHandler(w http.ResponseWriter, r *http.Request
 // @Tags			posts
 // @Accept			json
 // @Produce		json
-// @Param			postID			path		int			true	"Post ID"
-// @Param			title			body		string		false	"Post Title"		maxLength(100)
-// @Param			introduction	body		string		false	"Post Introduction"	maxLength(120)
-// @Param			content			body		string		false	"Post Content"		maxLength(1000)
-// @Param			category_id		body		int64		false	"Post Category ID"
-// @Param			thumbnail_image	body		string		false	"Thumbnail Image"
-// @Param			status			body		string		false	"Post Status"
-// @Success		200				{object}	store.Post	"Successfully updated post"
-// @Failure		400				{object}	error		"Invalid request, the request data was incorrect or malformed"
-// @Failure		404				{object}	error		"Post not found"
-// @Failure		409				{object}	error		"Conflict: The request could not be completed due to a conflict"
-// @Failure		500				{object}	error		"Internal server error, the server encountered a problem"
+// @Param			postID				path		int			true	"Post ID"
+// @Param			title				body		string		false	"Post Title"		maxLength(100)
+// @Param			introduction		body		string		false	"Post Introduction"	maxLength(120)
+// @Param			content				body		string		false	"Post Content"		maxLength(1000)
+// @Param			category_id			body		int64		false	"Post Category ID"
+// @Param			thumbnail_image		body		string		false	"Thumbnail Image"
+// @Param			thumbnail_media_id	body		int64		false	"ID of an uploaded image to use as thumbnail, overrides thumbnail_image"
+// @Param			status				body		string		false	"Post Status. Published requires an approval in categories that require review, content changes to approved posts or to published posts of such categories go back to review"	Enums(Draft, Submitted, Published)
+// @Param			tags				body		[]string	false	"Post Tags, replaces the current tags"
+// @Param			locale				body		string		false	"Language the post is written in, it must not have a translation in it"
+// @Param			If-Match			header		string		false	"ETag of the post, the update fails if the post changed since"
+// @Success		200					{object}	store.Post	"Successfully updated post"
+// @Header			200					{string}	ETag		"Version of the updated post"
+// @Failure		400					{object}	error		"Invalid request, the request data was incorrect or malformed"
+// @Failure		404					{object}	error		"Post not found"
+// @Failure		409					{object}	error		"Conflict: The request could not be completed due to a conflict"
+// @Failure		412					{object}	error		"The post changed since the If-Match version, the current version is returned"
+// @Failure		500					{object}	error		"Internal server error, the server encountered a problem"
 // @Security		ApiKeyAuth
 // @Router			/posts/{postID} [patch]
 func (app *application) updatePostHandler(w http.ResponseWriter, r *http.Request) {
 	post := getPostFromCtx(r)
+	user := getUserFromCtx(r)
+	previousStatus := post.Status
+	// published_at survives unpublishing, so followers hear of a post once
+	firstPublication := post.PublishedAt == nil
 
 	var payload UpdatePostPayload
 	if err := readJSON(w, r, &payload); err != nil {
@@ -219,22 +392,86 @@ func (app *application) updatePostHandler(w http.ResponseWriter, r *http.Request
 
 	if payload.ThumbnailImage != nil {
 		post.ThumbnailImage = *payload.ThumbnailImage
+		post.ThumbnailMediaID = nil
 	}
 
+	if payload.Tags != nil {
+		post.Tags = *payload.Tags
+	}
+
+	if payload.Locale != nil {
+		locale, err := app.service.Translations.ParseLocale(*payload.Locale)
+		if err != nil {
+			app.badRequestResponse(w, r, err)
+			return
+		}
+
+		if locale != post.Locale {
+			translated, err := app.hasTranslation(r.Context(), post.ID, locale)
+			if err != nil {
+				app.internalServerError(w, r, err)
+				return
+			}
+
+			if translated {
+				app.conflictResponse(w, r, fmt.Errorf("the post has a translation in %s, delete it first", locale))
+				return
+			}
+		}
+
+		post.Locale = locale
+	}
+
+	if payload.ThumbnailMediaID != nil {
+		thumbnail, err := app.resolveMedia(r.Context(), *payload.ThumbnailMediaID, getUserFromCtx(r))
+		if err != nil {
+			app.resolveMediaErrorResponse(w, r, err)
+			return
+		}
+
+		post.ThumbnailMediaID = &thumbnail.ID
+		post.ThumbnailImage = app.service.Media.URL(thumbnail, "large")
+	}
+
+	isModerator := app.canViewDrafts(user)
+
 	if payload.Status != nil {
 		post.Status = *payload.Status
 	}
 
+	// content changes to approved posts, or to published posts needing
+	// review, send them back to review
+	if err := app.service.Reviews.CheckEdit(r.Context(), post, previousStatus, payload.changesContent(), isModerator); err != nil {
+		app.statusTransitionErrorResponse(w, r, err)
+		return
+	}
+
 	if err := app.service.Posts.Update(r.Context(), post); err != nil {
 		switch {
 		case errors.Is(err, store.ErrNotFound):
-			app.conflictResponse(w, r, err)
+			app.postVersionConflictResponse(w, r, post.ID, err)
 		default:
 			app.internalServerError(w, r, err)
 		}
 		return
 	}
 
+	if err := app.service.Reviews.RecordStatusChange(r.Context(), post.ID, user.ID, previousStatus, post.Status); err != nil {
+		app.logger.Warnw("failed to record review history", "postID", post.ID, "error", err)
+	}
+
+	// a draft becoming public for the first time is announced to followers
+	// like a new post
+	if firstPublication && strings.EqualFold(post.Status, store.PostStatusPublished) {
+		author, err := app.service.Users.Get(r.Context(), post.UserID)
+		if err != nil {
+			app.logger.Warnw("failed to get post author", "error", err)
+		} else {
+			app.notifyFollowersOfNewPost(r.Context(), post, author)
+		}
+	}
+
+	w.Header().Set("ETag", postETag(post))
 	if err := app.jsonResponse(w, http.StatusOK, post); err != nil {
 		app.internalServerError(w, r, err)
 		return
@@ -274,21 +511,45 @@ func getPostFromCtx(r *http.Request) *store.Post {
 	return post
 }
 
+// canViewPost reports whether user may see post. Published posts are public,
+// drafts are only visible to their author, its co-authors and to moderators. Drafts are
+// reported as missing to everyone else so their existence is not leaked.
+func (app *application) canViewPost(user *store.User, post *store.Post) bool {
+	if strings.EqualFold(post.Status, store.PostStatusPublished) {
+		return true
+	}
+
+	if user == nil {
+		return false
+	}
+
+	if post.UserID == user.ID || post.CoAuthorPermission(user.ID) != "" {
+		return true
+	}
+
+	return app.canViewDrafts(user)
+}
+
 // @Summary		Create a new comment
-// @Description	Create a new comment on a post with the provided details
+// @Description	Create a new comment on a post with the provided details, or a reply to one of its comments. The author of the comment replied to is notified
 // @Tags			comments
 // @Accept			json
 // @Produce		json
-// @Param			postID	path		int64			true	"Post ID"
-// @Param			user_id	body		int64			true	"User ID"
-// @Param			content	body		string			true	"Comment Content"	maxLength(300)
-// @Success		201		{object}	store.Comment	"Successfully created comment"
-// @Failure		400		{object}	error			"Invalid request, the request data was incorrect or malformed"
-// @Failure		500		{object}	error			"Internal server error, the server encountered a problem"
+// @Param			postID		path		int64			true	"Post ID"
+// @Param			content		body		string			true	"Comment Content"	maxLength(300)
+// @Param			parent_id	body		int64			false	"ID of the comment replied to"
+// @Success		201			{object}	store.Comment	"Successfully created comment"
+// @Failure		400			{object}	error			"Invalid request, the comment replied to is not on the post or is nested too deep"
+// @Failure		500			{object}	error			"Internal server error, the server encountered a problem"
 // @Security		ApiKeyAuth
 // @Router			/comments [post]
 func (app *application) createCommentHandler(w http.ResponseWriter, r *http.Request) {
 	post := getPostFromCtx(r)
+	user := getUserFromCtx(r)
+	if !app.canViewPost(user, post) {
+		app.notFoundResponse(w, r, store.ErrNotFound)
+		return
+	}
 
 	var payload CreateCommentPayload
 	if err := readJSON(w, r, &payload); err != nil {
@@ -302,20 +563,25 @@ func (app *application) createCommentHandler(w http.ResponseWriter, r *http.Requ
 	}
 
 	comment := &store.Comment{
-		PostID:  post.ID,
-		UserID:  payload.UserID,
-		Content: payload.Content,
+		PostID:   post.ID,
+		UserID:   user.ID,
+		Content:  payload.Content,
+		ParentID: payload.ParentID,
 	}
 
 	ctx := r.Context()
 
 	if err := app.service.Comments.Create(ctx, comment); err != nil {
-		app.internalServerError(w, r, err)
+		switch {
+		case errors.Is(err, service.ErrParentCommentNotFound), errors.Is(err, service.ErrCommentTooDeep):
+			app.badRequestResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
 		return
 	}
 
 	// Create notification
-	user := getUserFromCtx(r)
 	comment.User = store.User{
 		ID:             user.ID,
 		Name:           user.Name,
@@ -323,7 +589,21 @@ func (app *application) createCommentHandler(w http.ResponseWriter, r *http.Requ
 		ProfilePicture: user.ProfilePicture,
 	}
 
-	if post.UserID != user.ID {
+	// the author of the comment replied to is told about the reply, when
+	// they wrote the post too they are not told about the comment as well
+	var replyRecipient int64
+	if comment.ParentID != nil {
+		notification, err := app.service.Notifications.CreateReplyNotification(ctx, comment, user.ID)
+		if err != nil {
+			app.logger.Warnw("failed to create notification", "error", err)
+		} else if notification != nil {
+			replyRecipient = notification.UserID
+			notification.Actor = &comment.User
+			app.sseManager.SendToUser(notification.UserID, notification)
+		}
+	}
+
+	if post.UserID != user.ID && post.UserID != replyRecipient {
 		if err := app.service.Notifications.CreateCommentNotification(r.Context(), post.ID, comment.ID, user.ID); err != nil {
 			app.logger.Warnw("failed to create notification", "error", err)
 		} else {
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+// postETag identifies a version of a post, it changes with every update
+func postETag(post *store.Post) string {
+	return fmt.Sprintf(`"%d-%d"`, post.ID, post.Version)
+}
+
+// hasPrecondition reports whether the client sent an If-Match header, the
+// requests without one keep the last write wins behavior
+func hasPrecondition(r *http.Request) bool {
+	return r.Header.Get("If-Match") != ""
+}
+
+// ifMatch reports whether the If-Match header lists etag. Weak tags never
+// match since If-Match uses the strong comparison. The representation hash
+// added by cacheResponse is ignored, only the version has to match.
+func ifMatch(r *http.Request, etag string) bool {
+	for _, tag := range strings.Split(r.Header.Get("If-Match"), ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+
+		if strings.HasPrefix(tag, "W/") {
+			continue
+		}
+
+		version, _, _ := strings.Cut(strings.Trim(tag, `"`), ".")
+		if `"`+version+`"` == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkPostPrecondition rejects the edits of clients that did not see the
+// current version of the post. It runs after the access checks so the version
+// is not leaked.
+func (app *application) checkPostPrecondition(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		post := getPostFromCtx(r)
+
+		if hasPrecondition(r) && !ifMatch(r, postETag(post)) {
+			app.preconditionFailedResponse(w, r, post)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// postVersionConflictResponse answers a write that lost against a concurrent
+// one. Clients that sent If-Match get the current version of the post.
+func (app *application) postVersionConflictResponse(w http.ResponseWriter, r *http.Request, postID int64, err error) {
+	if !hasPrecondition(r) {
+		app.conflictResponse(w, r, err)
+		return
+	}
+
+	current, err := app.service.Posts.Get(r.Context(), postID, 0)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	app.preconditionFailedResponse(w, r, current)
+}
+
+func (app *application) preconditionFailedResponse(w http.ResponseWriter, r *http.Request, post *store.Post) {
+	app.logger.Warnw("precondition failed", "method", r.Method, "path", r.URL.Path, "postID", post.ID, "version", post.Version)
+
+	type envelope struct {
+		Error          string `json:"error"`
+		CurrentVersion int    `json:"current_version"`
+	}
+
+	w.Header().Set("ETag", postETag(post))
+	writeJSON(w, http.StatusPreconditionFailed, &envelope{
+		Error:          "the post was modified since it was fetched",
+		CurrentVersion: post.Version,
+	})
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+const defaultPreviewTokenHours = 72
+
+type CreatePreviewTokenPayload struct {
+	ExpiresInHours int `json:"expires_in_hours" validate:"omitempty,min=1,max=720"`
+}
+
+type PreviewTokenResponse struct {
+	store.PreviewToken
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+// @Summary		Create a preview link
+// @Description	Create a shareable link that lets anyone read the post before it is published
+// @Tags			posts
+// @Accept			json
+// @Produce		json
+// @Param			postID				path		int						true	"Post ID"
+// @Param			expires_in_hours	body		int						false	"Hours until the link expires (default is 72, max is 720)"
+// @Success		201					{object}	PreviewTokenResponse	"Preview link created"
+// @Failure		400					{object}	error					"Invalid request"
+// @Failure		403					{object}	error					"Forbidden"
+// @Failure		404					{object}	error					"Post not found"
+// @Failure		500					{object}	error					"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/preview-tokens [post]
+func (app *application) createPreviewTokenHandler(w http.ResponseWriter, r *http.Request) {
+	var payload CreatePreviewTokenPayload
+	if err := readJSON(w, r, &payload); err != nil && !errors.Is(err, io.EOF) {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if payload.ExpiresInHours == 0 {
+		payload.ExpiresInHours = defaultPreviewTokenHours
+	}
+
+	post := getPostFromCtx(r)
+	user := getUserFromCtx(r)
+
+	plainToken, hashToken := app.service.Tokens.GeneratePreviewToken()
+	token := &store.PreviewToken{
+		PostID:    post.ID,
+		CreatedBy: user.ID,
+	}
+
+	exp := time.Duration(payload.ExpiresInHours) * time.Hour
+	if err := app.service.Previews.CreateToken(r.Context(), token, hashToken, exp); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	res := PreviewTokenResponse{
+		PreviewToken: *token,
+		Token:        plainToken,
+		URL:          fmt.Sprintf("%s/preview/%s", app.config.frontendURL, plainToken),
+	}
+
+	if err := app.jsonResponse(w, http.StatusCreated, res); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		List preview links
+// @Description	List the preview links of a post, including expired and revoked ones. The tokens themselves are not stored and cannot be listed
+// @Tags			posts
+// @Accept			json
+// @Produce		json
+// @Param			postID	path		int					true	"Post ID"
+// @Success		200		{array}		store.PreviewToken	"Preview links"
+// @Failure		403		{object}	error				"Forbidden"
+// @Failure		404		{object}	error				"Post not found"
+// @Failure		500		{object}	error				"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/preview-tokens [get]
+func (app *application) getPreviewTokensHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+
+	tokens, err := app.service.Previews.GetTokens(r.Context(), post.ID)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, tokens); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Revoke a preview link
+// @Description	Revoke a preview link so it can no longer be used
+// @Tags			posts
+// @Accept			json
+// @Produce		json
+// @Param			postID	path		int		true	"Post ID"
+// @Param			tokenID	path		int		true	"Preview token ID"
+// @Success		204		{string}	string	"Preview link revoked"
+// @Failure		400		{object}	error	"Invalid request"
+// @Failure		403		{object}	error	"Forbidden"
+// @Failure		404		{object}	error	"Preview link not found or already revoked"
+// @Failure		500		{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/preview-tokens/{tokenID} [delete]
+func (app *application) revokePreviewTokenHandler(w http.ResponseWriter, r *http.Request) {
+	tokenID, err := strconv.ParseInt(chi.URLParam(r, "tokenID"), 10, 64)
+	if err != nil || tokenID < 1 {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid token ID"))
+		return
+	}
+
+	post := getPostFromCtx(r)
+
+	if err := app.service.Previews.RevokeToken(r.Context(), post.ID, tokenID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Preview a post
+// @Description	Read a post, published or not, through a preview link. Expired and revoked links return 404
+// @Tags			posts
+// @Accept			json
+// @Produce		json
+// @Param			token	path		string		true	"Preview token"
+// @Success		200		{object}	store.Post	"Successfully fetched post"
+// @Failure		404		{object}	error		"Preview link not found, expired or revoked"
+// @Failure		500		{object}	error		"Internal server error"
+// @Router			/preview/{token} [get]
+func (app *application) getPostPreviewHandler(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	hashToken := app.service.Tokens.HashToken(token)
+
+	post, err := app.service.Previews.GetPost(r.Context(), hashToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	// preview pages are shared by link, keep them out of shared caches
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("X-Robots-Tag", "noindex")
+
+	if err := app.jsonResponse(w, http.StatusOK, post); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,417 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ritchie-gr8/my-blog-app/internal/cursor"
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type readingListKey string
+
+const readingListCtx readingListKey = "readingList"
+
+type CreateReadingListPayload struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"omitempty,max=1000"`
+}
+
+type UpdateReadingListPayload struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+}
+
+type AddReadingListPostPayload struct {
+	PostID int64 `json:"post_id" validate:"required,min=1"`
+}
+
+type ReorderReadingListPayload struct {
+	PostIDs []int64 `json:"post_ids" validate:"required,min=1,dive,min=1"`
+}
+
+// @Summary		Get my reading lists
+// @Description	Retrieve the reading lists of the current user by name
+// @Tags			reading-lists
+// @Accept			json
+// @Produce		json
+// @Success		200	{array}		store.ReadingList	"Successfully fetched reading lists"
+// @Failure		401	{object}	error				"User not authenticated"
+// @Failure		500	{object}	error				"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/reading-lists [get]
+func (app *application) getReadingListsHandler(w http.ResponseWriter, r *http.Request) {
+	user := getUserFromCtx(r)
+
+	lists, err := app.service.ReadingLists.GetByUserID(r.Context(), user.ID)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, lists); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Create a reading list
+// @Description	Create a named reading list for the current user. Reading lists are private
+// @Tags			reading-lists
+// @Accept			json
+// @Produce		json
+// @Param			name		body		string				true	"Reading list name"			maxLength(100)
+// @Param			description	body		string				false	"Reading list description"	maxLength(1000)
+// @Success		201			{object}	store.ReadingList	"Successfully created reading list"
+// @Failure		400			{object}	error				"Invalid request"
+// @Failure		401			{object}	error				"User not authenticated"
+// @Failure		409			{object}	error				"A reading list with this name already exists"
+// @Failure		500			{object}	error				"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/reading-lists [post]
+func (app *application) createReadingListHandler(w http.ResponseWriter, r *http.Request) {
+	var payload CreateReadingListPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	user := getUserFromCtx(r)
+	list := &store.ReadingList{
+		UserID:      user.ID,
+		Name:        payload.Name,
+		Description: payload.Description,
+	}
+
+	if err := app.service.ReadingLists.Create(r.Context(), list); err != nil {
+		switch {
+		case errors.Is(err, store.ErrUniqueViolation):
+			app.conflictResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusCreated, list); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Get a reading list
+// @Description	Retrieve a reading list of the current user and its posts in order, using cursor pagination
+// @Tags			reading-lists
+// @Accept			json
+// @Produce		json
+// @Param			listID	path		int							true	"Reading list ID"
+// @Param			cursor	query		string						false	"Opaque cursor from a previous response, empty for the first page"
+// @Param			limit	query		int							false	"Number of posts to retrieve (default is 20, max is 50)"	minimum(1)	maximum(50)
+// @Success		200		{object}	service.ReadingListResponse	"Successfully fetched reading list"
+// @Failure		400		{object}	error						"Invalid request"
+// @Failure		404		{object}	error						"Reading list not found"
+// @Failure		500		{object}	error						"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/reading-lists/{listID} [get]
+func (app *application) getReadingListHandler(w http.ResponseWriter, r *http.Request) {
+	list := getReadingListFromCtx(r)
+
+	limit := 20
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed < 1 || parsed > 50 {
+			app.badRequestResponse(w, r, fmt.Errorf("limit must be between 1 and 50"))
+			return
+		}
+		limit = parsed
+	}
+
+	page, err := app.service.ReadingLists.GetPage(r.Context(), list, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, cursor.ErrInvalidCursor):
+			app.badRequestResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, page); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Update a reading list
+// @Description	Rename a reading list or change its description
+// @Tags			reading-lists
+// @Accept			json
+// @Produce		json
+// @Param			listID		path		int					true	"Reading list ID"
+// @Param			name		body		string				false	"Reading list name"			maxLength(100)
+// @Param			description	body		string				false	"Reading list description"	maxLength(1000)
+// @Success		200			{object}	store.ReadingList	"Successfully updated reading list"
+// @Failure		400			{object}	error				"Invalid request"
+// @Failure		404			{object}	error				"Reading list not found"
+// @Failure		409			{object}	error				"A reading list with this name already exists"
+// @Failure		500			{object}	error				"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/reading-lists/{listID} [patch]
+func (app *application) updateReadingListHandler(w http.ResponseWriter, r *http.Request) {
+	list := getReadingListFromCtx(r)
+
+	var payload UpdateReadingListPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if payload.Name != nil {
+		list.Name = *payload.Name
+	}
+
+	if payload.Description != nil {
+		list.Description = *payload.Description
+	}
+
+	if err := app.service.ReadingLists.Update(r.Context(), list); err != nil {
+		switch {
+		case errors.Is(err, store.ErrUniqueViolation):
+			app.conflictResponse(w, r, err)
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, list); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Delete a reading list
+// @Description	Delete a reading list. Its posts and bookmarks are kept
+// @Tags			reading-lists
+// @Accept			json
+// @Produce		json
+// @Param			listID	path		int		true	"Reading list ID"
+// @Success		204		{string}	string	"Reading list deleted"
+// @Failure		404		{object}	error	"Reading list not found"
+// @Failure		500		{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/reading-lists/{listID} [delete]
+func (app *application) deleteReadingListHandler(w http.ResponseWriter, r *http.Request) {
+	list := getReadingListFromCtx(r)
+
+	if err := app.service.ReadingLists.Delete(r.Context(), list.ID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Add a post to a reading list
+// @Description	Append a post to the end of a reading list
+// @Tags			reading-lists
+// @Accept			json
+// @Produce		json
+// @Param			listID	path		int		true	"Reading list ID"
+// @Param			post_id	body		int64	true	"Post ID"
+// @Success		201		{object}	object	"Post added, returns its position"
+// @Failure		400		{object}	error	"Invalid request"
+// @Failure		404		{object}	error	"Reading list or post not found"
+// @Failure		409		{object}	error	"Post already in the reading list"
+// @Failure		500		{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/reading-lists/{listID}/posts [post]
+func (app *application) addReadingListPostHandler(w http.ResponseWriter, r *http.Request) {
+	list := getReadingListFromCtx(r)
+
+	var payload AddReadingListPostPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	user := getUserFromCtx(r)
+
+	post, err := app.service.Posts.Get(ctx, payload.PostID, 0)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if !app.canViewPost(user, post) {
+		app.notFoundResponse(w, r, store.ErrNotFound)
+		return
+	}
+
+	position, err := app.service.ReadingLists.AddPost(ctx, list.ID, post.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrUniqueViolation):
+			app.conflictResponse(w, r, err)
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	res := map[string]any{
+		"reading_list_id": list.ID,
+		"post_id":         post.ID,
+		"position":        position,
+	}
+
+	if err := app.jsonResponse(w, http.StatusCreated, res); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Remove a post from a reading list
+// @Description	Remove a post from a reading list, the following posts move up one place
+// @Tags			reading-lists
+// @Accept			json
+// @Produce		json
+// @Param			listID	path		int		true	"Reading list ID"
+// @Param			postID	path		int		true	"Post ID"
+// @Success		204		{string}	string	"Post removed"
+// @Failure		400		{object}	error	"Invalid request"
+// @Failure		404		{object}	error	"Post not in the reading list"
+// @Failure		500		{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/reading-lists/{listID}/posts/{postID} [delete]
+func (app *application) removeReadingListPostHandler(w http.ResponseWriter, r *http.Request) {
+	list := getReadingListFromCtx(r)
+
+	postID, err := strconv.ParseInt(chi.URLParam(r, "postID"), 10, 64)
+	if err != nil || postID < 1 {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid post ID"))
+		return
+	}
+
+	if err := app.service.ReadingLists.RemovePost(r.Context(), list.ID, postID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Reorder a reading list
+// @Description	Set the order of the posts of a reading list. Every post of the list must be listed exactly once
+// @Tags			reading-lists
+// @Accept			json
+// @Produce		json
+// @Param			listID		path		int		true	"Reading list ID"
+// @Param			post_ids	body		[]int64	true	"Post IDs in their new order"
+// @Success		204			{string}	string	"Reading list reordered"
+// @Failure		400			{object}	error	"Invalid request or incomplete order"
+// @Failure		404			{object}	error	"Reading list not found"
+// @Failure		500			{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/reading-lists/{listID}/posts/order [put]
+func (app *application) reorderReadingListHandler(w http.ResponseWriter, r *http.Request) {
+	list := getReadingListFromCtx(r)
+
+	var payload ReorderReadingListPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := app.service.ReadingLists.Reorder(r.Context(), list.ID, payload.PostIDs); err != nil {
+		switch {
+		case errors.Is(err, store.ErrReadingListOrderMismatch):
+			app.badRequestResponse(w, r, err)
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// readingListContextMiddleware loads the reading list of the URL. Reading
+// lists are private, the lists of other users are reported as not found.
+func (app *application) readingListContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(chi.URLParam(r, "listID"), 10, 64)
+		if err != nil || id < 1 {
+			app.badRequestResponse(w, r, fmt.Errorf("invalid reading list ID"))
+			return
+		}
+
+		ctx := r.Context()
+
+		list, err := app.service.ReadingLists.Get(ctx, id)
+		if err != nil {
+			switch {
+			case errors.Is(err, store.ErrNotFound):
+				app.notFoundResponse(w, r, err)
+			default:
+				app.internalServerError(w, r, err)
+			}
+			return
+		}
+
+		if list.UserID != getUserFromCtx(r).ID {
+			app.notFoundResponse(w, r, store.ErrNotFound)
+			return
+		}
+
+		ctx = context.WithValue(ctx, readingListCtx, list)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func getReadingListFromCtx(r *http.Request) *store.ReadingList {
+	list, _ := r.Context().Value(readingListCtx).(*store.ReadingList)
+	return list
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+// @Summary		Get related posts
+// @Description	Retrieve published posts related to a post, ranked by shared category, title similarity, readers who liked both and recency
+// @Tags			posts
+// @Accept			json
+// @Produce		json
+// @Param			postID	path		int					true	"Post ID"
+// @Param			limit	query		int					false	"Number of posts to retrieve (default is 5, max is 20)"	minimum(1)	maximum(20)
+// @Success		200		{array}		store.RelatedPost	"Successfully fetched related posts"
+// @Failure		400		{object}	error				"Invalid request"
+// @Failure		404		{object}	error				"Post not found"
+// @Failure		500		{object}	error				"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/related [get]
+func (app *application) getRelatedPostsHandler(w http.ResponseWriter, r *http.Request) {
+	limit := 5
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed < 1 || parsed > 20 {
+			app.badRequestResponse(w, r, fmt.Errorf("limit must be between 1 and 20"))
+			return
+		}
+		limit = parsed
+	}
+
+	post := getPostFromCtx(r)
+	user := getUserFromCtx(r)
+
+	if !app.canViewPost(user, post) {
+		app.notFoundResponse(w, r, store.ErrNotFound)
+		return
+	}
+
+	posts, err := app.service.Posts.GetRelated(r.Context(), post.ID, limit)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, posts); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
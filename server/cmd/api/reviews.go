@@ -0,0 +1,157 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type CreateReviewPayload struct {
+	Action string `json:"action" validate:"required,oneof=started approved changes_requested comment"`
+	Note   string `json:"note" validate:"max=2000"`
+}
+
+// @Summary		Review a post
+// @Description	Act on a post in the editorial review. started takes a submitted post in review, approved approves it for publishing,
+// @Description	changes_requested sends it back to its author with a note, and comment only leaves a note. The author is notified. Moderators only, not on their own posts
+// @Tags			reviews
+// @Accept			json
+// @Produce		json
+// @Param			postID	path		int				true	"Post ID"
+// @Param			action	body		string			true	"Review action"											Enums(started, approved, changes_requested, comment)
+// @Param			note	body		string			false	"Review note, required to request changes or comment"	maxLength(2000)
+// @Success		201		{object}	store.Review	"Successfully reviewed post"
+// @Failure		400		{object}	error			"Invalid request"
+// @Failure		403		{object}	error			"Forbidden"
+// @Failure		404		{object}	error			"Post not found"
+// @Failure		409		{object}	error			"The post is not at a step this action applies to"
+// @Failure		500		{object}	error			"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/reviews [post]
+func (app *application) createReviewHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+	user := getUserFromCtx(r)
+
+	var payload CreateReviewPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if payload.Note == "" && (payload.Action == store.ReviewActionChangesRequested || payload.Action == store.ReviewActionComment) {
+		app.badRequestResponse(w, r, fmt.Errorf("a note is required to %s", payload.Action))
+		return
+	}
+
+	if post.UserID == user.ID {
+		app.forbiddenResponse(w, r)
+		return
+	}
+
+	ctx := r.Context()
+
+	review, err := app.service.Reviews.Review(ctx, post, user.ID, payload.Action, payload.Note)
+	if err != nil {
+		app.statusTransitionErrorResponse(w, r, err)
+		return
+	}
+	review.UserName = user.Name
+
+	notification, err := app.service.Notifications.CreateReviewNotification(ctx, review, post.UserID)
+	if err != nil {
+		app.logger.Warnw("failed to create notification", "error", err)
+	} else {
+		notification.Actor = &store.User{
+			ID:             user.ID,
+			Name:           user.Name,
+			Username:       user.Username,
+			ProfilePicture: user.ProfilePicture,
+		}
+		app.sseManager.SendToUser(post.UserID, notification)
+	}
+
+	if err := app.jsonResponse(w, http.StatusCreated, review); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Get the review history of a post
+// @Description	Retrieve every step of the editorial review of a post with its notes, oldest first. Visible to the author, the co-authors and moderators
+// @Tags			reviews
+// @Accept			json
+// @Produce		json
+// @Param			postID	path		int				true	"Post ID"
+// @Success		200		{array}		store.Review	"Successfully fetched review history"
+// @Failure		403		{object}	error			"Forbidden"
+// @Failure		404		{object}	error			"Post not found"
+// @Failure		500		{object}	error			"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/reviews [get]
+func (app *application) getReviewsHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+
+	reviews, err := app.service.Reviews.GetHistory(r.Context(), post.ID)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, reviews); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Get the review queue
+// @Description	Retrieve the posts waiting for a review, the longest waiting first. Moderators only
+// @Tags			reviews
+// @Accept			json
+// @Produce		json
+// @Param			status	query		string						false	"Only list posts at this step"	Enums(Submitted, In Review)
+// @Param			page	query		int							false	"Page number"					default(1)
+// @Param			limit	query		int							false	"Limit results"					default(20)
+// @Success		200		{object}	service.ReviewQueueResponse	"Successfully fetched review queue"
+// @Failure		400		{object}	error						"Invalid request"
+// @Failure		401		{object}	error						"User not authenticated"
+// @Failure		403		{object}	error						"Forbidden"
+// @Failure		500		{object}	error						"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/reviews/queue [get]
+func (app *application) getReviewQueueHandler(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	if status != "" && status != store.PostStatusSubmitted && status != store.PostStatusInReview {
+		app.badRequestResponse(w, r, fmt.Errorf("status must be %q or %q", store.PostStatusSubmitted, store.PostStatusInReview))
+		return
+	}
+
+	page, limit := getPageParams(r, 20, 100)
+
+	queue, err := app.service.Reviews.GetQueue(r.Context(), status, page, limit)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, queue); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// statusTransitionErrorResponse writes the response for a refused change of
+// the status of a post
+func (app *application) statusTransitionErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, store.ErrInvalidTransition), errors.Is(err, store.ErrReviewRequired):
+		app.conflictResponse(w, r, err)
+	case errors.Is(err, store.ErrNotFound):
+		app.badRequestResponse(w, r, fmt.Errorf("category not found"))
+	default:
+		app.internalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+// @Summary		Search posts
+// @Description	Full-text search over published posts, ranked by relevance. Supports web search syntax: "quoted phrases", OR and -excluded words
+// @Tags			posts
+// @Accept			json
+// @Produce		json
+// @Param			q			query		string					true	"Search terms (max length is 200)"
+// @Param			page		query		int						false	"Page number for pagination (default is 1)"				minimum(1)
+// @Param			limit		query		int						false	"Number of posts to retrieve (default is 6, max is 20)"	minimum(1)	maximum(20)
+// @Param			category	query		string					false	"Category to filter posts by"
+// @Success		200			{object}	service.SearchResponse	"Successfully retrieved the search results"
+// @Failure		400			{object}	error					"Invalid request, the request data was incorrect or malformed"
+// @Failure		500			{object}	error					"Internal server error, the server encountered a problem"
+// @Router			/search [get]
+func (app *application) searchPostsHandler(w http.ResponseWriter, r *http.Request) {
+	sq := store.PaginatedSearchQuery{
+		Limit: 6,
+		Page:  1,
+	}
+
+	sq, err := sq.Parse(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(sq); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	results, err := app.service.Posts.Search(r.Context(), sq)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, results); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/env"
+)
+
+// dedicatedSecret returns the secret set in the name environment variable.
+// When it is not set, a key is derived from the master secret for purpose,
+// so that it differs from the master secret and from the keys of the other
+// purposes. It fails when neither secret is set: an empty key would make the
+// signatures forgeable.
+func dedicatedSecret(name, master, purpose string) (string, error) {
+	if secret := env.GetString(name, ""); secret != "" {
+		return secret, nil
+	}
+
+	if master == "" {
+		return "", fmt.Errorf("%s is required when AUTH_TOKEN_SECRET is not set", name)
+	}
+
+	key, err := hkdf.Key(sha256.New, []byte(master), nil, "my-blog-app "+purpose, 32)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(key), nil
+}
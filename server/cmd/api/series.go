@@ -0,0 +1,413 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ritchie-gr8/my-blog-app/internal/cursor"
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type seriesKey string
+
+const seriesCtx seriesKey = "series"
+
+type CreateSeriesPayload struct {
+	Title       string `json:"title" validate:"required,max=150"`
+	Description string `json:"description" validate:"omitempty,max=1000"`
+}
+
+type UpdateSeriesPayload struct {
+	Title       *string `json:"title" validate:"omitempty,min=1,max=150"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+}
+
+type AddSeriesPostPayload struct {
+	PostID int64 `json:"post_id" validate:"required,min=1"`
+}
+
+type ReorderSeriesPayload struct {
+	PostIDs []int64 `json:"post_ids" validate:"required,min=1,dive,min=1"`
+}
+
+// @Summary		Create a series
+// @Description	Create an ordered series to group the posts of the current user
+// @Tags			series
+// @Accept			json
+// @Produce		json
+// @Param			title		body		string			true	"Series title"			maxLength(150)
+// @Param			description	body		string			false	"Series description"	maxLength(1000)
+// @Success		201			{object}	store.Series	"Successfully created series"
+// @Failure		400			{object}	error			"Invalid request"
+// @Failure		401			{object}	error			"User not authenticated"
+// @Failure		500			{object}	error			"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/series [post]
+func (app *application) createSeriesHandler(w http.ResponseWriter, r *http.Request) {
+	var payload CreateSeriesPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	user := getUserFromCtx(r)
+	series := &store.Series{
+		UserID:      user.ID,
+		Title:       payload.Title,
+		Description: payload.Description,
+		AuthorName:  user.Name,
+	}
+
+	if err := app.service.Series.Create(r.Context(), series); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusCreated, series); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Get a series
+// @Description	Retrieve a series and its posts in order, using cursor pagination. Drafts are only listed for the author and moderators
+// @Tags			series
+// @Accept			json
+// @Produce		json
+// @Param			seriesID	path		int						true	"Series ID"
+// @Param			cursor		query		string					false	"Opaque cursor from a previous response, empty for the first page"
+// @Param			limit		query		int						false	"Number of posts to retrieve (default is 10, max is 50)"	minimum(1)	maximum(50)
+// @Success		200			{object}	service.SeriesResponse	"Successfully fetched series"
+// @Failure		400			{object}	error					"Invalid request"
+// @Failure		404			{object}	error					"Series not found"
+// @Failure		500			{object}	error					"Internal server error"
+// @Router			/series/{seriesID} [get]
+func (app *application) getSeriesHandler(w http.ResponseWriter, r *http.Request) {
+	series := getSeriesFromCtx(r)
+
+	limit := 10
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed < 1 || parsed > 50 {
+			app.badRequestResponse(w, r, fmt.Errorf("limit must be between 1 and 50"))
+			return
+		}
+		limit = parsed
+	}
+
+	includeDrafts := false
+	if user := getUserFromCtx(r); user != nil {
+		includeDrafts = user.ID == series.UserID || app.canViewDrafts(user)
+	}
+
+	page, err := app.service.Series.GetPage(r.Context(), series, r.URL.Query().Get("cursor"), limit, includeDrafts)
+	if err != nil {
+		switch {
+		case errors.Is(err, cursor.ErrInvalidCursor):
+			app.badRequestResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, page); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Update a series
+// @Description	Update the title or description of a series
+// @Tags			series
+// @Accept			json
+// @Produce		json
+// @Param			seriesID	path		int				true	"Series ID"
+// @Param			title		body		string			false	"Series title"			maxLength(150)
+// @Param			description	body		string			false	"Series description"	maxLength(1000)
+// @Success		200			{object}	store.Series	"Successfully updated series"
+// @Failure		400			{object}	error			"Invalid request"
+// @Failure		403			{object}	error			"Forbidden"
+// @Failure		404			{object}	error			"Series not found"
+// @Failure		500			{object}	error			"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/series/{seriesID} [patch]
+func (app *application) updateSeriesHandler(w http.ResponseWriter, r *http.Request) {
+	series := getSeriesFromCtx(r)
+
+	var payload UpdateSeriesPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if payload.Title != nil {
+		series.Title = *payload.Title
+	}
+
+	if payload.Description != nil {
+		series.Description = *payload.Description
+	}
+
+	if err := app.service.Series.Update(r.Context(), series); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, series); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Delete a series
+// @Description	Delete a series. Its posts are kept
+// @Tags			series
+// @Accept			json
+// @Produce		json
+// @Param			seriesID	path		int		true	"Series ID"
+// @Success		204			{string}	string	"Series deleted"
+// @Failure		403			{object}	error	"Forbidden"
+// @Failure		404			{object}	error	"Series not found"
+// @Failure		500			{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/series/{seriesID} [delete]
+func (app *application) deleteSeriesHandler(w http.ResponseWriter, r *http.Request) {
+	series := getSeriesFromCtx(r)
+
+	if err := app.service.Series.Delete(r.Context(), series.ID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Add a post to a series
+// @Description	Append a post of the series author to the end of the series. A post can only belong to one series
+// @Tags			series
+// @Accept			json
+// @Produce		json
+// @Param			seriesID	path		int		true	"Series ID"
+// @Param			post_id		body		int64	true	"Post ID"
+// @Success		201			{object}	object	"Post added, returns its position"
+// @Failure		400			{object}	error	"Invalid request or post of another author"
+// @Failure		403			{object}	error	"Forbidden"
+// @Failure		404			{object}	error	"Series or post not found"
+// @Failure		409			{object}	error	"Post already belongs to a series"
+// @Failure		500			{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/series/{seriesID}/posts [post]
+func (app *application) addSeriesPostHandler(w http.ResponseWriter, r *http.Request) {
+	series := getSeriesFromCtx(r)
+
+	var payload AddSeriesPostPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+
+	post, err := app.service.Posts.Get(ctx, payload.PostID, 0)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if post.UserID != series.UserID {
+		app.badRequestResponse(w, r, fmt.Errorf("only posts of the series author can be added"))
+		return
+	}
+
+	position, err := app.service.Series.AddPost(ctx, series.ID, post.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrUniqueViolation):
+			app.conflictResponse(w, r, err)
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	res := map[string]any{
+		"series_id": series.ID,
+		"post_id":   post.ID,
+		"position":  position,
+	}
+
+	if err := app.jsonResponse(w, http.StatusCreated, res); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Remove a post from a series
+// @Description	Remove a post from a series, the following posts move up one place
+// @Tags			series
+// @Accept			json
+// @Produce		json
+// @Param			seriesID	path		int		true	"Series ID"
+// @Param			postID		path		int		true	"Post ID"
+// @Success		204			{string}	string	"Post removed"
+// @Failure		400			{object}	error	"Invalid request"
+// @Failure		403			{object}	error	"Forbidden"
+// @Failure		404			{object}	error	"Post not in the series"
+// @Failure		500			{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/series/{seriesID}/posts/{postID} [delete]
+func (app *application) removeSeriesPostHandler(w http.ResponseWriter, r *http.Request) {
+	series := getSeriesFromCtx(r)
+
+	postID, err := strconv.ParseInt(chi.URLParam(r, "postID"), 10, 64)
+	if err != nil || postID < 1 {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid post ID"))
+		return
+	}
+
+	if err := app.service.Series.RemovePost(r.Context(), series.ID, postID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Reorder a series
+// @Description	Set the order of the posts of a series. Every post of the series, drafts included, must be listed exactly once
+// @Tags			series
+// @Accept			json
+// @Produce		json
+// @Param			seriesID	path		int		true	"Series ID"
+// @Param			post_ids	body		[]int64	true	"Post IDs in their new order"
+// @Success		204			{string}	string	"Series reordered"
+// @Failure		400			{object}	error	"Invalid request or incomplete order"
+// @Failure		403			{object}	error	"Forbidden"
+// @Failure		404			{object}	error	"Series not found"
+// @Failure		500			{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/series/{seriesID}/posts/order [put]
+func (app *application) reorderSeriesHandler(w http.ResponseWriter, r *http.Request) {
+	series := getSeriesFromCtx(r)
+
+	var payload ReorderSeriesPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := app.service.Series.Reorder(r.Context(), series.ID, payload.PostIDs); err != nil {
+		switch {
+		case errors.Is(err, store.ErrSeriesOrderMismatch):
+			app.badRequestResponse(w, r, err)
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (app *application) seriesContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(chi.URLParam(r, "seriesID"), 10, 64)
+		if err != nil || id < 1 {
+			app.badRequestResponse(w, r, fmt.Errorf("invalid series ID"))
+			return
+		}
+
+		ctx := r.Context()
+
+		series, err := app.service.Series.Get(ctx, id)
+		if err != nil {
+			switch {
+			case errors.Is(err, store.ErrNotFound):
+				app.notFoundResponse(w, r, err)
+			default:
+				app.internalServerError(w, r, err)
+			}
+			return
+		}
+
+		ctx = context.WithValue(ctx, seriesCtx, series)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func getSeriesFromCtx(r *http.Request) *store.Series {
+	series, _ := r.Context().Value(seriesCtx).(*store.Series)
+	return series
+}
+
+// checkSeriesOwnership lets through the series author and users with at
+// least requiredRole, like checkPostOwnership does for posts
+func (app *application) checkSeriesOwnership(requiredRole string, next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := getUserFromCtx(r)
+		series := getSeriesFromCtx(r)
+
+		if series.UserID == user.ID {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		allowed, err := app.checkRolePrecedence(user, requiredRole)
+		if err != nil {
+			app.internalServerError(w, r, err)
+			return
+		}
+
+		if !allowed {
+			app.forbiddenResponse(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
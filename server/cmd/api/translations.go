@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ritchie-gr8/my-blog-app/cmd/service"
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+	"golang.org/x/text/language"
+)
+
+type PutTranslationPayload struct {
+	Title        string `json:"title" validate:"required,max=100"`
+	Introduction string `json:"introduction" validate:"required,max=120"`
+	Content      string `json:"content" validate:"required,max=1000"`
+	Slug         string `json:"slug" validate:"omitempty,max=200"`
+}
+
+// @Summary		Get the translations of a post
+// @Description	Retrieve every translation of a post, ordered by locale
+// @Tags			translations
+// @Accept			json
+// @Produce		json
+// @Param			postID	path		int					true	"Post ID"
+// @Success		200		{array}		store.Translation	"Successfully fetched translations"
+// @Failure		404		{object}	error				"Post not found"
+// @Failure		500		{object}	error				"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/translations [get]
+func (app *application) getTranslationsHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+
+	if !app.canViewPost(getUserFromCtx(r), post) {
+		app.notFoundResponse(w, r, store.ErrNotFound)
+		return
+	}
+
+	translations, err := app.service.Translations.GetByPostID(r.Context(), post.ID)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, translations); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Create or replace a translation
+// @Description	Create or replace the translation of a post in a locale. The slug is derived from the title when it is omitted and must be unique within the locale
+// @Tags			translations
+// @Accept			json
+// @Produce		json
+// @Param			postID			path		int					true	"Post ID"
+// @Param			locale			path		string				true	"Locale of the translation, one of the supported locales"
+// @Param			title			body		string				true	"Translated title"			maxLength(100)
+// @Param			introduction	body		string				true	"Translated introduction"	maxLength(120)
+// @Param			content			body		string				true	"Translated content"		maxLength(1000)
+// @Param			slug			body		string				false	"Slug of the translation"	maxLength(200)
+// @Success		200				{object}	store.Translation	"Successfully replaced translation"
+// @Success		201				{object}	store.Translation	"Successfully created translation"
+// @Failure		400				{object}	error				"Invalid request, unsupported locale or the locale of the post itself"
+// @Failure		403				{object}	error				"Forbidden"
+// @Failure		404				{object}	error				"Post not found"
+// @Failure		409				{object}	error				"The slug is already used in this locale"
+// @Failure		500				{object}	error				"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/translations/{locale} [put]
+func (app *application) putTranslationHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+
+	var payload PutTranslationPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	translation := &store.Translation{
+		Locale:       chi.URLParam(r, "locale"),
+		Title:        payload.Title,
+		Introduction: payload.Introduction,
+		Content:      payload.Content,
+		Slug:         payload.Slug,
+	}
+
+	created, err := app.service.Translations.Put(r.Context(), post, translation)
+	if err != nil {
+		app.translationErrorResponse(w, r, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+
+	if err := app.jsonResponse(w, status, translation); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Delete a translation
+// @Description	Delete the translation of a post in a locale
+// @Tags			translations
+// @Accept			json
+// @Produce		json
+// @Param			postID	path			int		true	"Post ID"
+// @Param			locale	path			string	true	"Locale of the translation"
+// @Success		204		"No Content"	"Successfully deleted translation"
+// @Failure		400		{object}		error	"Unsupported locale"
+// @Failure		403		{object}		error	"Forbidden"
+// @Failure		404		{object}		error	"Post or translation not found"
+// @Failure		500		{object}		error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/{postID}/translations/{locale} [delete]
+func (app *application) deleteTranslationHandler(w http.ResponseWriter, r *http.Request) {
+	post := getPostFromCtx(r)
+
+	if err := app.service.Translations.Delete(r.Context(), post.ID, chi.URLParam(r, "locale")); err != nil {
+		app.translationErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Find a post by translated slug
+// @Description	Redirect to the post translated to a locale under a slug, in that locale
+// @Tags			translations
+// @Produce		json
+// @Param			locale	path	string	true	"Locale of the translation"
+// @Param			slug	path	string	true	"Slug of the translation"
+// @Success		302		"Found"
+// @Header			302		{string}	Location	"Path of the post in the locale"
+// @Failure		400		{object}	error		"Unsupported locale"
+// @Failure		404		{object}	error		"Translation not found"
+// @Failure		500		{object}	error		"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/posts/slug/{locale}/{slug} [get]
+func (app *application) getPostBySlugHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	locale, err := app.service.Translations.ParseLocale(chi.URLParam(r, "locale"))
+	if err != nil {
+		app.translationErrorResponse(w, r, err)
+		return
+	}
+
+	postID, err := app.service.Translations.GetPostIDBySlug(ctx, locale, chi.URLParam(r, "slug"))
+	if err != nil {
+		app.translationErrorResponse(w, r, err)
+		return
+	}
+
+	// the ids of drafts are not revealed to who cannot read them
+	post, err := app.service.Posts.Get(ctx, postID, 0)
+	if err != nil {
+		app.translationErrorResponse(w, r, err)
+		return
+	}
+
+	if !app.canViewPost(getUserFromCtx(r), post) {
+		app.notFoundResponse(w, r, store.ErrNotFound)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/v1/posts/%d?lang=%s", postID, locale), http.StatusFound)
+}
+
+func (app *application) translationErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrUnsupportedLocale),
+		errors.Is(err, service.ErrOriginalLocale),
+		errors.Is(err, service.ErrInvalidSlug):
+		app.badRequestResponse(w, r, err)
+	case errors.Is(err, store.ErrUniqueViolation):
+		app.conflictResponse(w, r, fmt.Errorf("slug already used in this locale"))
+	case errors.Is(err, store.ErrNotFound):
+		app.notFoundResponse(w, r, err)
+	default:
+		app.internalServerError(w, r, err)
+	}
+}
+
+// hasTranslation reports whether a post is translated to locale
+func (app *application) hasTranslation(ctx context.Context, postID int64, locale string) (bool, error) {
+	translations, err := app.service.Translations.GetByPostID(ctx, postID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, t := range translations {
+		if t.Locale == locale {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// preferredLanguages returns the languages asked for by a request. The lang
+// param takes precedence over the Accept-Language header, a malformed header
+// is ignored like a missing one.
+func preferredLanguages(r *http.Request) ([]language.Tag, error) {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		tag, err := language.Parse(lang)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lang: %s", lang)
+		}
+		return []language.Tag{tag}, nil
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if err != nil {
+		return nil, nil
+	}
+
+	return tags, nil
+}
+
+// setLanguageHeaders describes the language of a localized post and links to
+// its other languages
+func setLanguageHeaders(w http.ResponseWriter, post *store.Post) {
+	w.Header().Set("Content-Language", post.Locale)
+	w.Header().Add("Vary", "Accept-Language")
+
+	links := make([]string, 0, len(post.Alternates))
+	for _, alternate := range post.Alternates {
+		links = append(links, fmt.Sprintf(`<%s>; rel="alternate"; hreflang="%s"`, alternate.URL, alternate.Locale))
+	}
+
+	if len(links) > 0 {
+		w.Header().Add("Link", strings.Join(links, ", "))
+	}
+}
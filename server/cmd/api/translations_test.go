@@ -0,0 +1,67 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+func TestPreferredLanguages(t *testing.T) {
+	t.Run("should prefer the lang param over Accept-Language", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/?lang=th", nil)
+		req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+
+		tags, err := preferredLanguages(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(tags) != 1 || tags[0].String() != "th" {
+			t.Errorf("expected [th]; got %v", tags)
+		}
+	})
+
+	t.Run("should read Accept-Language by quality", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", "en;q=0.5, th-TH")
+
+		tags, err := preferredLanguages(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(tags) != 2 || tags[0].String() != "th-TH" || tags[1].String() != "en" {
+			t.Errorf("expected [th-TH en]; got %v", tags)
+		}
+	})
+
+	t.Run("should reject an invalid lang param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/?lang=e%21", nil)
+
+		if _, err := preferredLanguages(req); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestSetLanguageHeaders(t *testing.T) {
+	rr := httptest.NewRecorder()
+	setLanguageHeaders(rr, &store.Post{
+		Locale: "th",
+		Alternates: []store.Alternate{
+			{Locale: "en", URL: "/v1/posts/1?lang=en", Original: true},
+			{Locale: "th", URL: "/v1/posts/1?lang=th"},
+		},
+	})
+
+	if lang := rr.Header().Get("Content-Language"); lang != "th" {
+		t.Errorf("unexpected Content-Language %q", lang)
+	}
+
+	expected := `</v1/posts/1?lang=en>; rel="alternate"; hreflang="en", </v1/posts/1?lang=th>; rel="alternate"; hreflang="th"`
+	if link := rr.Header().Get("Link"); link != expected {
+		t.Errorf("unexpected Link %q", link)
+	}
+}
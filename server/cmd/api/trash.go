@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type trashedPostKey string
+
+const trashedPostCtx trashedPostKey = "trashedPost"
+
+// @Summary		Get my trash
+// @Description	Retrieve the posts the current user deleted that have not been purged yet, most recently deleted first
+// @Tags			trash
+// @Accept			json
+// @Produce		json
+// @Param			page	query		int						false	"Page number"	default(1)
+// @Param			limit	query		int						false	"Limit results"	default(20)
+// @Success		200		{object}	service.TrashResponse	"Successfully fetched trash"
+// @Failure		401		{object}	error					"User not authenticated"
+// @Failure		500		{object}	error					"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/trash [get]
+func (app *application) getTrashHandler(w http.ResponseWriter, r *http.Request) {
+	user := getUserFromCtx(r)
+	app.trashListHandler(w, r, user.ID)
+}
+
+// @Summary		Get all trash
+// @Description	Retrieve the deleted posts of every author that have not been purged yet. Admin only
+// @Tags			trash
+// @Accept			json
+// @Produce		json
+// @Param			page	query		int						false	"Page number"	default(1)
+// @Param			limit	query		int						false	"Limit results"	default(20)
+// @Success		200		{object}	service.TrashResponse	"Successfully fetched trash"
+// @Failure		401		{object}	error					"User not authenticated"
+// @Failure		403		{object}	error					"Forbidden"
+// @Failure		500		{object}	error					"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/trash/all [get]
+func (app *application) getAllTrashHandler(w http.ResponseWriter, r *http.Request) {
+	app.trashListHandler(w, r, 0)
+}
+
+func (app *application) trashListHandler(w http.ResponseWriter, r *http.Request, userID int64) {
+	page, limit := getPageParams(r, 20, 100)
+
+	trash, err := app.service.Posts.GetTrash(r.Context(), userID, page, limit)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, trash); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// @Summary		Restore a post
+// @Description	Take a deleted post out of the trash, along with its comments and likes
+// @Tags			trash
+// @Accept			json
+// @Produce		json
+// @Param			postID	path		int		true	"Post ID"
+// @Success		204		{string}	string	"Post restored"
+// @Failure		400		{object}	error	"Invalid request"
+// @Failure		403		{object}	error	"Forbidden"
+// @Failure		404		{object}	error	"Post not found in the trash"
+// @Failure		500		{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/trash/{postID}/restore [post]
+func (app *application) restorePostHandler(w http.ResponseWriter, r *http.Request) {
+	post := getTrashedPostFromCtx(r)
+
+	if err := app.service.Posts.Restore(r.Context(), post.ID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Purge a post
+// @Description	Permanently delete a post from the trash, along with its comments and likes. This cannot be undone
+// @Tags			trash
+// @Accept			json
+// @Produce		json
+// @Param			postID	path		int		true	"Post ID"
+// @Success		204		{string}	string	"Post purged"
+// @Failure		400		{object}	error	"Invalid request"
+// @Failure		403		{object}	error	"Forbidden"
+// @Failure		404		{object}	error	"Post not found in the trash"
+// @Failure		500		{object}	error	"Internal server error"
+// @Security		ApiKeyAuth
+// @Router			/trash/{postID} [delete]
+func (app *application) purgePostHandler(w http.ResponseWriter, r *http.Request) {
+	post := getTrashedPostFromCtx(r)
+
+	if err := app.service.Posts.Purge(r.Context(), post.ID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// trashedPostContextMiddleware loads a trashed post and lets through its
+// author and admins, the same users that may delete it in the first place
+func (app *application) trashedPostContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(chi.URLParam(r, "postID"), 10, 64)
+		if err != nil || id < 1 {
+			app.badRequestResponse(w, r, fmt.Errorf("invalid post ID"))
+			return
+		}
+
+		ctx := r.Context()
+
+		post, err := app.service.Posts.GetTrashed(ctx, id)
+		if err != nil {
+			switch {
+			case errors.Is(err, store.ErrNotFound):
+				app.notFoundResponse(w, r, err)
+			default:
+				app.internalServerError(w, r, err)
+			}
+			return
+		}
+
+		user := getUserFromCtx(r)
+		if post.UserID != user.ID {
+			allowed, err := app.checkRolePrecedence(user, "admin")
+			if err != nil {
+				app.internalServerError(w, r, err)
+				return
+			}
+
+			if !allowed {
+				app.forbiddenResponse(w, r)
+				return
+			}
+		}
+
+		ctx = context.WithValue(ctx, trashedPostCtx, post)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func getTrashedPostFromCtx(r *http.Request) *store.TrashedPost {
+	post, _ := r.Context().Value(trashedPostCtx).(*store.TrashedPost)
+	return post
+}
@@ -18,7 +18,9 @@ type UpdateUserPayload struct {
 	Username       *string `json:"username" validate:"omitempty,max=72"`
 	Email          *string `json:"email" validate:"omitempty,email,max=120"`
 	Bio            *string `json:"bio" validate:"omitempty,max=120"`
-	ProfilePicture *string `json:"profile_picture" validate:"omitempty"`
+	ProfilePicture *string `json:"profile_picture" validate:"omitempty,max=255"`
+	// ProfilePictureMediaID takes precedence over ProfilePicture
+	ProfilePictureMediaID *int64 `json:"profile_picture_media_id" validate:"omitempty,min=1"`
 }
 
 type ResetPasswordPayload struct {
@@ -66,16 +68,17 @@ func (app *application) getUserHandler(w http.ResponseWriter, r *http.Request) {
 // @Tags			users
 // @Accept			json
 // @Produce		json
-// @Param			userID			path		int64		true	"User ID"
-// @Param			name			body		string		false	"Name"								maxLength(72)
-// @Param			username		body		string		false	"Username"							maxLength(72)
-// @Param			email			body		string		false	"Email"								maxLength(120)	format(email)
-// @Param			bio				body		string		false	"Bio"								maxLength(120)
-// @Param			profile_picture	body		string		false	"Profile Picture (base64 encoded)"	maxLength(1000000)
-// @Success		200				{object}	store.User	"Successfully updated user"
-// @Failure		400				{object}	error		"Invalid request, the request data was incorrect or malformed"
-// @Failure		404				{object}	error		"User not found"
-// @Failure		500				{object}	error		"Internal server error, the server encountered a problem"
+// @Param			userID						path		int64		true	"User ID"
+// @Param			name						body		string		false	"Name"					maxLength(72)
+// @Param			username					body		string		false	"Username"				maxLength(72)
+// @Param			email						body		string		false	"Email"					maxLength(120)	format(email)
+// @Param			bio							body		string		false	"Bio"					maxLength(120)
+// @Param			profile_picture				body		string		false	"Profile Picture URL"	maxLength(255)
+// @Param			profile_picture_media_id	body		int64		false	"ID of an uploaded image to use as profile picture, overrides profile_picture"
+// @Success		200							{object}	store.User	"Successfully updated user"
+// @Failure		400							{object}	error		"Invalid request, the request data was incorrect or malformed"
+// @Failure		404							{object}	error		"User not found"
+// @Failure		500							{object}	error		"Internal server error, the server encountered a problem"
 // @Security		ApiKeyAuth
 // @Router			/users/{userID} [patch]
 func (app *application) updateUserHandler(w http.ResponseWriter, r *http.Request) {
@@ -125,6 +128,18 @@ func (app *application) updateUserHandler(w http.ResponseWriter, r *http.Request
 
 	if payload.ProfilePicture != nil {
 		user.ProfilePicture = *payload.ProfilePicture
+		user.ProfilePictureMediaID = nil
+	}
+
+	if payload.ProfilePictureMediaID != nil {
+		picture, err := app.resolveMedia(r.Context(), *payload.ProfilePictureMediaID, getUserFromCtx(r))
+		if err != nil {
+			app.resolveMediaErrorResponse(w, r, err)
+			return
+		}
+
+		user.ProfilePictureMediaID = &picture.ID
+		user.ProfilePicture = app.service.Media.URL(picture, "thumbnail")
 	}
 
 	err = app.service.Users.Update(r.Context(), user)
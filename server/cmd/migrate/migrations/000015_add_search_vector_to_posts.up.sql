@@ -0,0 +1,23 @@
+ALTER TABLE posts
+ADD COLUMN search_language regconfig NOT NULL DEFAULT 'english',
+ADD COLUMN search_vector tsvector;
+
+-- title > introduction > content
+CREATE OR REPLACE FUNCTION posts_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector(NEW.search_language, coalesce(NEW.title, '')), 'A') ||
+        setweight(to_tsvector(NEW.search_language, coalesce(NEW.introduction, '')), 'B') ||
+        setweight(to_tsvector(NEW.search_language, coalesce(NEW.content, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER posts_search_vector_trigger
+BEFORE INSERT OR UPDATE OF title, introduction, content, search_language ON posts
+FOR EACH ROW EXECUTE FUNCTION posts_search_vector_update();
+
+-- backfill existing rows through the trigger
+UPDATE posts SET title = title;
+
+CREATE INDEX IF NOT EXISTS idx_posts_search_vector ON posts USING gin (search_vector);
@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_posts_created_at;
+
+DROP TRIGGER IF EXISTS post_stats_comments_trigger ON comments;
+
+DROP TRIGGER IF EXISTS post_stats_likes_trigger ON post_likes;
+
+DROP TRIGGER IF EXISTS post_stats_insert_trigger ON posts;
+
+DROP FUNCTION IF EXISTS post_stats_count_update();
+
+DROP FUNCTION IF EXISTS post_stats_insert();
+
+DROP FUNCTION IF EXISTS post_trending_score(BIGINT, BIGINT, TIMESTAMPTZ);
+
+DROP TABLE IF EXISTS post_stats;
@@ -0,0 +1,81 @@
+CREATE TABLE IF NOT EXISTS post_stats (
+    post_id BIGINT PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    likes_count BIGINT NOT NULL DEFAULT 0,
+    comments_count BIGINT NOT NULL DEFAULT 0,
+    trending_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    score_updated_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- likes count once, comments twice; decays with the age of the post in hours.
+-- The background job re-applies the decay, the triggers below keep it fresh on activity.
+CREATE OR REPLACE FUNCTION post_trending_score(likes BIGINT, comments BIGINT, created_at TIMESTAMPTZ)
+RETURNS DOUBLE PRECISION AS $$
+    SELECT CASE
+        WHEN created_at < NOW() - INTERVAL '30 days' THEN 0
+        ELSE (likes + 2 * comments) / power(EXTRACT(EPOCH FROM NOW() - created_at) / 3600 + 2, 1.8)
+    END
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION post_stats_insert() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO post_stats (post_id) VALUES (NEW.id) ON CONFLICT (post_id) DO NOTHING;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER post_stats_insert_trigger
+AFTER INSERT ON posts
+FOR EACH ROW EXECUTE FUNCTION post_stats_insert();
+
+CREATE OR REPLACE FUNCTION post_stats_count_update() RETURNS trigger AS $$
+DECLARE
+    target_post_id BIGINT;
+    delta BIGINT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        target_post_id := NEW.post_id;
+        delta := 1;
+    ELSE
+        target_post_id := OLD.post_id;
+        delta := -1;
+    END IF;
+
+    IF TG_TABLE_NAME = 'post_likes' THEN
+        UPDATE post_stats SET likes_count = GREATEST(likes_count + delta, 0) WHERE post_id = target_post_id;
+    ELSE
+        UPDATE post_stats SET comments_count = GREATEST(comments_count + delta, 0) WHERE post_id = target_post_id;
+    END IF;
+
+    UPDATE post_stats ps
+    SET trending_score = post_trending_score(ps.likes_count, ps.comments_count, p.created_at),
+        score_updated_at = NOW()
+    FROM posts p
+    WHERE p.id = ps.post_id AND ps.post_id = target_post_id;
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER post_stats_likes_trigger
+AFTER INSERT OR DELETE ON post_likes
+FOR EACH ROW EXECUTE FUNCTION post_stats_count_update();
+
+CREATE TRIGGER post_stats_comments_trigger
+AFTER INSERT OR DELETE ON comments
+FOR EACH ROW EXECUTE FUNCTION post_stats_count_update();
+
+INSERT INTO post_stats (post_id, likes_count, comments_count, trending_score)
+SELECT p.id, l.likes_count, c.comments_count,
+    post_trending_score(l.likes_count, c.comments_count, p.created_at)
+FROM posts p
+CROSS JOIN LATERAL (SELECT COUNT(*) AS likes_count FROM post_likes pl WHERE pl.post_id = p.id) l
+CROSS JOIN LATERAL (SELECT COUNT(*) AS comments_count FROM comments cm WHERE cm.post_id = p.id) c
+ON CONFLICT (post_id) DO NOTHING;
+
+CREATE INDEX IF NOT EXISTS idx_post_stats_trending ON post_stats (trending_score DESC, post_id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_post_stats_likes ON post_stats (likes_count DESC, post_id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_post_stats_comments ON post_stats (comments_count DESC, post_id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_posts_created_at ON posts (created_at);
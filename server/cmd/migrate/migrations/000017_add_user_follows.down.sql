@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS user_follows_followee_id_idx;
+
+DROP TABLE IF EXISTS user_follows;
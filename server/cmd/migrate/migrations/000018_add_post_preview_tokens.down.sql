@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS post_preview_tokens_post_id_idx;
+
+DROP TABLE IF EXISTS post_preview_tokens;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS posts_deleted_at_idx;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS deleted_at;
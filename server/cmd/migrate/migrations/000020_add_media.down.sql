@@ -0,0 +1,6 @@
+ALTER TABLE users DROP COLUMN IF EXISTS profile_picture_media_id;
+ALTER TABLE posts DROP COLUMN IF EXISTS thumbnail_media_id;
+
+DROP INDEX IF EXISTS media_user_id_idx;
+
+DROP TABLE IF EXISTS media;
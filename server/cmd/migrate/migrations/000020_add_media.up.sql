@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS media (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    content_type TEXT NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    -- one entry per rendition: name, storage key, content type, size and dimensions
+    variants JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS media_user_id_idx ON media(user_id);
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS thumbnail_media_id BIGINT REFERENCES media(id) ON DELETE SET NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS profile_picture_media_id BIGINT REFERENCES media(id) ON DELETE SET NULL;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS series_posts;
+
+DROP INDEX IF EXISTS series_user_id_idx;
+
+DROP TABLE IF EXISTS series;
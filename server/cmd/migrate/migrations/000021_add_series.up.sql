@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS series (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS series_user_id_idx ON series(user_id);
+
+-- a post belongs to at most one series. Positions are checked at commit so
+-- a reorder can swap them inside a transaction.
+CREATE TABLE IF NOT EXISTS series_posts (
+    series_id BIGINT NOT NULL REFERENCES series(id) ON DELETE CASCADE,
+    post_id BIGINT NOT NULL UNIQUE REFERENCES posts(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    PRIMARY KEY (series_id, post_id),
+    CONSTRAINT series_posts_position_key UNIQUE (series_id, position) DEFERRABLE INITIALLY DEFERRED
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_post_likes_post_id_created_at;
+
+DROP INDEX IF EXISTS idx_comments_post_id_created_at;
+
+DROP TABLE IF EXISTS post_views_daily;
@@ -0,0 +1,11 @@
+-- views are deduplicated and buffered by the API, then added here in batches
+CREATE TABLE IF NOT EXISTS post_views_daily (
+    post_id BIGINT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    views BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, day)
+);
+
+CREATE INDEX IF NOT EXISTS idx_comments_post_id_created_at ON comments (post_id, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_post_likes_post_id_created_at ON post_likes (post_id, created_at);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS reading_list_posts;
+
+DROP TABLE IF EXISTS reading_lists;
+
+DROP TABLE IF EXISTS bookmarks;
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS bookmarks (
+    id BIGSERIAL PRIMARY KEY,
+    post_id BIGINT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE(post_id, user_id)
+);
+
+-- serves the keyset pagination of GET /users/me/bookmarks
+CREATE INDEX IF NOT EXISTS bookmarks_user_id_created_at_idx ON bookmarks(user_id, created_at DESC, id DESC);
+
+CREATE TABLE IF NOT EXISTS reading_lists (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE(user_id, name)
+);
+
+-- positions are checked at commit so a reorder can swap them inside a
+-- transaction, like series_posts
+CREATE TABLE IF NOT EXISTS reading_list_posts (
+    list_id BIGINT NOT NULL REFERENCES reading_lists(id) ON DELETE CASCADE,
+    post_id BIGINT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    added_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (list_id, post_id),
+    CONSTRAINT reading_list_posts_position_key UNIQUE (list_id, position) DEFERRABLE INITIALLY DEFERRED
+);
+
+CREATE INDEX IF NOT EXISTS reading_list_posts_post_id_idx ON reading_list_posts(post_id);
@@ -0,0 +1,13 @@
+-- co-authors are invited by the post author and only get their permission
+-- once they accept
+CREATE TABLE IF NOT EXISTS post_coauthors (
+    post_id BIGINT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    permission TEXT NOT NULL CHECK (permission IN ('editor', 'viewer')),
+    invited_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    accepted_at TIMESTAMP(0) WITH TIME ZONE,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS post_coauthors_user_id_idx ON post_coauthors(user_id);
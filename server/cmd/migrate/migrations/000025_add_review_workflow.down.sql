@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS posts_review_queue_idx;
+
+DROP TABLE IF EXISTS post_reviews;
+
+ALTER TABLE categories DROP COLUMN IF EXISTS requires_review;
+
+UPDATE posts SET status = 'Draft' WHERE status NOT IN ('Draft', 'Published');
+
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS valid_post_status;
+ALTER TABLE posts ADD CONSTRAINT valid_post_status CHECK (status IN ('Draft', 'Published'));
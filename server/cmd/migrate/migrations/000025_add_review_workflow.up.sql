@@ -0,0 +1,23 @@
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS valid_post_status;
+ALTER TABLE posts ADD CONSTRAINT valid_post_status
+    CHECK (status IN ('Draft', 'Submitted', 'In Review', 'Approved', 'Changes requested', 'Published'));
+
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS requires_review BOOLEAN NOT NULL DEFAULT false;
+
+-- every step of the review of a post, notes included
+CREATE TABLE IF NOT EXISTS post_reviews (
+    id BIGSERIAL PRIMARY KEY,
+    post_id BIGINT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    action TEXT NOT NULL
+        CHECK (action IN ('submitted', 'withdrawn', 'started', 'approved', 'changes_requested', 'comment', 'published')),
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS post_reviews_post_id_created_at_idx ON post_reviews(post_id, created_at);
+
+CREATE INDEX IF NOT EXISTS posts_review_queue_idx ON posts(updated_at)
+    WHERE status IN ('Submitted', 'In Review') AND deleted_at IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS posts_user_id_import_key_idx;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS import_key,
+DROP COLUMN IF EXISTS tags;
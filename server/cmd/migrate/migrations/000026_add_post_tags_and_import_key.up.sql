@@ -0,0 +1,7 @@
+ALTER TABLE posts
+ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}',
+-- import_key identifies posts imported from Markdown files so importing the
+-- same archive again updates them instead of creating duplicates
+ADD COLUMN IF NOT EXISTS import_key TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS posts_user_id_import_key_idx ON posts(user_id, import_key);
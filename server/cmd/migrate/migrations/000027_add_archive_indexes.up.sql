@@ -0,0 +1,6 @@
+-- author filter of the feed, ordered like the default feed
+CREATE INDEX IF NOT EXISTS posts_user_id_created_at_idx ON posts(user_id, created_at DESC);
+
+-- date archive and date ranges of the public feed
+CREATE INDEX IF NOT EXISTS posts_published_created_at_idx ON posts(created_at)
+WHERE deleted_at IS NULL AND status = 'Published';
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS post_translations;
+
+DROP INDEX IF EXISTS posts_locale_idx;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS locale;
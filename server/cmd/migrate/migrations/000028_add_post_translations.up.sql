@@ -0,0 +1,21 @@
+-- locale is the language the post itself is written in, translations into
+-- other locales live in post_translations
+ALTER TABLE posts
+ADD COLUMN IF NOT EXISTS locale TEXT NOT NULL DEFAULT 'en';
+
+CREATE INDEX IF NOT EXISTS posts_locale_idx ON posts(locale);
+
+CREATE TABLE IF NOT EXISTS post_translations (
+    post_id BIGINT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    locale TEXT NOT NULL,
+    title TEXT NOT NULL,
+    introduction TEXT NOT NULL,
+    content TEXT NOT NULL,
+    slug TEXT NOT NULL,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, locale)
+);
+
+-- slugs identify a translation in its locale
+CREATE UNIQUE INDEX IF NOT EXISTS post_translations_locale_slug_idx ON post_translations(locale, slug);
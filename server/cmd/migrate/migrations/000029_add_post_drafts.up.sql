@@ -0,0 +1,18 @@
+-- the working copy of a post, autosaved while it is edited and copied onto
+-- the post when it is published. revision counts the saves to detect
+-- concurrent editors, base_version is the version of the post it started from.
+CREATE TABLE IF NOT EXISTS post_drafts (
+    post_id BIGINT PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    revision INT NOT NULL DEFAULT 1,
+    base_version INT NOT NULL,
+    title TEXT NOT NULL,
+    introduction TEXT NOT NULL,
+    content TEXT NOT NULL,
+    category_id BIGINT REFERENCES categories(id) ON DELETE SET NULL,
+    thumbnail_image TEXT NOT NULL DEFAULT '',
+    thumbnail_media_id BIGINT REFERENCES media(id) ON DELETE SET NULL,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bulk_operation_items;
+
+DROP TABLE IF EXISTS bulk_operations;
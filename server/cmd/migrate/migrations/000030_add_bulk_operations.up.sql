@@ -0,0 +1,31 @@
+-- bulk operations run by admins. Background operations are processed in
+-- batches of rows ordered by id, last_id is where the next batch starts so an
+-- interrupted operation resumes where it stopped.
+CREATE TABLE IF NOT EXISTS bulk_operations (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    target TEXT NOT NULL CHECK (target IN ('posts', 'comments')),
+    action TEXT NOT NULL,
+    value TEXT NOT NULL DEFAULT '',
+    filter JSONB NOT NULL DEFAULT '{}',
+    background BOOLEAN NOT NULL DEFAULT false,
+    status TEXT NOT NULL CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    total BIGINT NOT NULL DEFAULT 0,
+    processed BIGINT NOT NULL DEFAULT 0,
+    last_id BIGINT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP(0) WITH TIME ZONE,
+    finished_at TIMESTAMP(0) WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS bulk_operations_unfinished_idx ON bulk_operations(id)
+WHERE status IN ('pending', 'running');
+
+-- what an operation changed, previous holds the values it overwrote
+CREATE TABLE IF NOT EXISTS bulk_operation_items (
+    operation_id BIGINT NOT NULL REFERENCES bulk_operations(id) ON DELETE CASCADE,
+    target_id BIGINT NOT NULL,
+    previous JSONB NOT NULL DEFAULT '{}',
+    PRIMARY KEY (operation_id, target_id)
+);
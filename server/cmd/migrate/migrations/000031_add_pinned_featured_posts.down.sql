@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS featured_posts;
+
+DROP TABLE IF EXISTS pinned_posts;
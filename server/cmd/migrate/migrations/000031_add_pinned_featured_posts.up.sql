@@ -0,0 +1,22 @@
+-- posts listed before the others on the first page of the feed. global pins
+-- show on the main feed and on the feed of the category of the post,
+-- category pins only on the latter. Expired pins are ignored.
+CREATE TABLE IF NOT EXISTS pinned_posts (
+    post_id BIGINT PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    scope TEXT NOT NULL DEFAULT 'global' CHECK (scope IN ('global', 'category')),
+    position INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP(0) WITH TIME ZONE,
+    user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- posts of the homepage carousel, ordered by position
+CREATE TABLE IF NOT EXISTS featured_posts (
+    post_id BIGINT PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    position INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP(0) WITH TIME ZONE,
+    user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS featured_posts_position_idx ON featured_posts(position, created_at DESC);
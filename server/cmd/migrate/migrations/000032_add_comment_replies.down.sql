@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS comment_replies_count_trigger ON comments;
+
+DROP FUNCTION IF EXISTS comment_replies_count_update();
+
+DROP INDEX IF EXISTS idx_comments_parent_id;
+
+ALTER TABLE comments
+    DROP COLUMN IF EXISTS replies_count,
+    DROP COLUMN IF EXISTS depth,
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS comment_likes_count_trigger ON comment_likes;
+
+DROP FUNCTION IF EXISTS comment_likes_count_update();
+
+DROP INDEX IF EXISTS idx_comments_post_id_likes_count;
+
+ALTER TABLE comments
+    DROP COLUMN IF EXISTS likes_count;
+
+DROP TABLE IF EXISTS comment_likes;
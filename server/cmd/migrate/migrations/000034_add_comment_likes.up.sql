@@ -0,0 +1,32 @@
+-- likes_count counts the likes of a comment, it is its score when the
+-- comments of a post are sorted by top
+CREATE TABLE IF NOT EXISTS comment_likes (
+    comment_id BIGINT NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (comment_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_likes_user_id ON comment_likes (user_id);
+
+ALTER TABLE comments
+    ADD COLUMN IF NOT EXISTS likes_count BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_comments_post_id_likes_count ON comments (post_id, likes_count DESC, id DESC)
+    WHERE parent_id IS NULL;
+
+CREATE OR REPLACE FUNCTION comment_likes_count_update() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE comments SET likes_count = likes_count + 1 WHERE id = NEW.comment_id;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE comments SET likes_count = GREATEST(likes_count - 1, 0) WHERE id = OLD.comment_id;
+    END IF;
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER comment_likes_count_trigger
+AFTER INSERT OR DELETE ON comment_likes
+FOR EACH ROW EXECUTE FUNCTION comment_likes_count_update();
@@ -0,0 +1,17 @@
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'posts', 'post_stats', 'post_likes', 'post_translations', 'post_coauthors', 'pinned_posts',
+        'featured_posts', 'comments', 'comment_likes', 'categories', 'users', 'user_follows',
+        'bookmarks', 'series', 'series_posts', 'media'
+    ] LOOP
+        EXECUTE format('DROP TRIGGER IF EXISTS content_version_trigger ON %I', t);
+    END LOOP;
+END
+$$;
+
+DROP FUNCTION IF EXISTS content_version_bump();
+
+DROP SEQUENCE IF EXISTS content_version;
@@ -0,0 +1,28 @@
+-- content_version changes with every write to the tables the cacheable
+-- responses are built from. The API answers conditional requests without
+-- rebuilding the response while it is unchanged. It is a sequence rather
+-- than a row so writes do not wait on each other to bump it.
+CREATE SEQUENCE IF NOT EXISTS content_version;
+
+CREATE OR REPLACE FUNCTION content_version_bump() RETURNS trigger AS $$
+BEGIN
+    PERFORM nextval('content_version');
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'posts', 'post_stats', 'post_likes', 'post_translations', 'post_coauthors', 'pinned_posts',
+        'featured_posts', 'comments', 'comment_likes', 'categories', 'users', 'user_follows',
+        'bookmarks', 'series', 'series_posts', 'media'
+    ] LOOP
+        EXECUTE format('DROP TRIGGER IF EXISTS content_version_trigger ON %I', t);
+        EXECUTE format('CREATE TRIGGER content_version_trigger AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON %I '
+            'FOR EACH STATEMENT EXECUTE FUNCTION content_version_bump()', t);
+    END LOOP;
+END
+$$;
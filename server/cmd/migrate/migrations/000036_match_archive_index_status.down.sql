@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS posts_published_created_at_idx;
+
+CREATE INDEX IF NOT EXISTS posts_published_created_at_idx ON posts(created_at)
+WHERE deleted_at IS NULL AND status = 'Published';
@@ -0,0 +1,6 @@
+-- the feed and the archive filter on status ILIKE 'published', the partial
+-- index is only used when its predicate is written the same way
+DROP INDEX IF EXISTS posts_published_created_at_idx;
+
+CREATE INDEX IF NOT EXISTS posts_published_created_at_idx ON posts(created_at)
+WHERE deleted_at IS NULL AND status ILIKE 'published';
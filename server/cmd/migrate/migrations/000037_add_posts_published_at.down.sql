@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS posts_published_at_trigger ON posts;
+
+DROP FUNCTION IF EXISTS posts_published_at_update();
+
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS published_at;
@@ -0,0 +1,23 @@
+-- published_at is the first time a post was published, it is kept when the
+-- post is unpublished so followers are only told about it once
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS published_at TIMESTAMP(0) WITH TIME ZONE;
+
+-- posts published before, or already announced to followers
+UPDATE posts p SET published_at = p.created_at
+WHERE p.published_at IS NULL AND (p.status ILIKE 'published'
+    OR EXISTS(SELECT 1 FROM notifications n WHERE n.type = 'new_post' AND n.related_id = p.id));
+
+CREATE OR REPLACE FUNCTION posts_published_at_update() RETURNS trigger AS $$
+BEGIN
+    IF NEW.published_at IS NULL AND NEW.status ILIKE 'published' THEN
+        NEW.published_at := NOW();
+    END IF;
+
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER posts_published_at_trigger
+BEFORE INSERT OR UPDATE OF status ON posts
+FOR EACH ROW EXECUTE FUNCTION posts_published_at_update();
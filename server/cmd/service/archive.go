@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type ArchiveResponse struct {
+	Months []store.ArchiveMonth `json:"months"`
+	Total  int64                `json:"total"`
+}
+
+func (s *PostService) GetArchive(ctx context.Context, aq store.ArchiveQuery) (*ArchiveResponse, error) {
+	months, err := s.store.Posts.GetArchive(ctx, aq)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for i := range months {
+		first := time.Date(months[i].Year, time.Month(months[i].Month), 1, 0, 0, 0, 0, time.UTC)
+		months[i].From = first.Format(time.DateOnly)
+		months[i].To = first.AddDate(0, 1, -1).Format(time.DateOnly)
+		total += months[i].Count
+	}
+
+	return &ArchiveResponse{
+		Months: months,
+		Total:  total,
+	}, nil
+}
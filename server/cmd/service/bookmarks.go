@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/cursor"
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type BookmarkService struct {
+	store   store.Storage
+	cursors *cursor.Signer
+}
+
+// BookmarksResponse is a page of bookmarks, most recent first
+type BookmarksResponse struct {
+	Items      []store.BookmarkedPost `json:"items"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+// Bookmark saves a post for later, bookmarking it twice is a no-op
+func (s *BookmarkService) Bookmark(ctx context.Context, postID, userID int64) error {
+	return s.store.Bookmarks.Bookmark(ctx, postID, userID)
+}
+
+func (s *BookmarkService) Unbookmark(ctx context.Context, postID, userID int64) error {
+	return s.store.Bookmarks.Unbookmark(ctx, postID, userID)
+}
+
+func (s *BookmarkService) HasUserBookmarked(ctx context.Context, postID, userID int64) (bool, error) {
+	return s.store.Bookmarks.HasUserBookmarked(ctx, postID, userID)
+}
+
+// GetBookmarks returns a page of the bookmarks of a user. An empty cursor
+// starts from the most recent one.
+func (s *BookmarkService) GetBookmarks(ctx context.Context, userID int64, rawCursor string, limit int) (*BookmarksResponse, error) {
+	var after *store.BookmarkCursor
+	if rawCursor != "" {
+		var c store.BookmarkCursor
+		if err := s.cursors.Decode(rawCursor, &c); err != nil {
+			return nil, err
+		}
+		after = &c
+	}
+
+	posts, hasMore, err := s.store.Bookmarks.GetByUserID(ctx, userID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &BookmarksResponse{Items: posts}
+
+	if hasMore {
+		last := posts[len(posts)-1]
+		res.NextCursor, err = s.cursors.Encode(store.BookmarkCursor{CreatedAt: last.BookmarkedAt, ID: last.BookmarkID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+type ReadingListService struct {
+	store   store.Storage
+	cursors *cursor.Signer
+}
+
+// ReadingListResponse is a reading list with one page of its posts
+type ReadingListResponse struct {
+	ReadingList *store.ReadingList      `json:"reading_list"`
+	Items       []store.ReadingListPost `json:"items"`
+	NextCursor  string                  `json:"next_cursor,omitempty"`
+}
+
+func (s *ReadingListService) Create(ctx context.Context, list *store.ReadingList) error {
+	return s.store.ReadingLists.Create(ctx, list)
+}
+
+func (s *ReadingListService) Get(ctx context.Context, id int64) (*store.ReadingList, error) {
+	return s.store.ReadingLists.GetByID(ctx, id)
+}
+
+func (s *ReadingListService) GetByUserID(ctx context.Context, userID int64) ([]store.ReadingList, error) {
+	return s.store.ReadingLists.GetByUserID(ctx, userID)
+}
+
+func (s *ReadingListService) Update(ctx context.Context, list *store.ReadingList) error {
+	return s.store.ReadingLists.Update(ctx, list)
+}
+
+func (s *ReadingListService) Delete(ctx context.Context, id int64) error {
+	return s.store.ReadingLists.Delete(ctx, id)
+}
+
+func (s *ReadingListService) AddPost(ctx context.Context, listID, postID int64) (int, error) {
+	return s.store.ReadingLists.AddPost(ctx, listID, postID)
+}
+
+func (s *ReadingListService) RemovePost(ctx context.Context, listID, postID int64) error {
+	return s.store.ReadingLists.RemovePost(ctx, listID, postID)
+}
+
+func (s *ReadingListService) Reorder(ctx context.Context, listID int64, postIDs []int64) error {
+	return s.store.ReadingLists.Reorder(ctx, listID, postIDs)
+}
+
+// GetPage returns a reading list with one page of its posts. An empty cursor
+// starts from the top of the list.
+func (s *ReadingListService) GetPage(ctx context.Context, list *store.ReadingList, rawCursor string, limit int) (*ReadingListResponse, error) {
+	var after int
+	if rawCursor != "" {
+		var c store.ReadingListCursor
+		if err := s.cursors.Decode(rawCursor, &c); err != nil {
+			return nil, err
+		}
+
+		if c.ListID != list.ID {
+			return nil, cursor.ErrInvalidCursor
+		}
+
+		after = c.Position
+	}
+
+	posts, hasMore, err := s.store.ReadingLists.GetPosts(ctx, list.ID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ReadingListResponse{
+		ReadingList: list,
+		Items:       posts,
+	}
+
+	if hasMore {
+		last := posts[len(posts)-1]
+		res.NextCursor, err = s.cursors.Encode(store.ReadingListCursor{ListID: list.ID, Position: last.Position})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
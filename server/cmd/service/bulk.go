@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+	"go.uber.org/zap"
+)
+
+const (
+	// bulkSyncLimit is the largest number of rows an operation changes in a
+	// single transaction, larger ones have to run in the background
+	bulkSyncLimit = 1000
+	// bulkBatchSize is the number of rows a background operation changes
+	// per transaction
+	bulkBatchSize = 500
+)
+
+var ErrBulkTooLarge = errors.New("too many rows for a single transaction, run the operation in the background")
+
+type BulkDryRunResponse struct {
+	Matched int64 `json:"matched"`
+	// RequiresBackground is set when the operation is too large to run in a
+	// single transaction
+	RequiresBackground bool `json:"requires_background"`
+}
+
+type BulkOperationsResponse struct {
+	Items      []store.BulkOperation `json:"items"`
+	Total      int64                 `json:"total"`
+	Page       int                   `json:"page"`
+	PageSize   int                   `json:"page_size"`
+	TotalPages int                   `json:"total_pages"`
+}
+
+type BulkOperationItemsResponse struct {
+	Items      []store.BulkOperationItem `json:"items"`
+	Total      int64                     `json:"total"`
+	Page       int                       `json:"page"`
+	PageSize   int                       `json:"page_size"`
+	TotalPages int                       `json:"total_pages"`
+}
+
+type BulkService struct {
+	store  store.Storage
+	posts  *PostService
+	logger *zap.SugaredLogger
+}
+
+// DryRun counts the rows an operation would change without changing them
+func (s *BulkService) DryRun(ctx context.Context, op *store.BulkOperation) (*BulkDryRunResponse, error) {
+	matched, _, err := s.store.BulkOperations.Count(ctx, op.Target, op.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BulkDryRunResponse{
+		Matched:            matched,
+		RequiresBackground: matched > bulkSyncLimit,
+	}, nil
+}
+
+// Start runs an operation in a single transaction, or queues it for the
+// background job. The matched rows are frozen when it starts, rows created
+// afterwards are not changed.
+func (s *BulkService) Start(ctx context.Context, op *store.BulkOperation) error {
+	total, maxID, err := s.store.BulkOperations.Count(ctx, op.Target, op.Filter)
+	if err != nil {
+		return err
+	}
+
+	op.Total = total
+	op.Filter.MaxID = maxID
+
+	if op.Background {
+		return s.store.BulkOperations.Create(ctx, op)
+	}
+
+	if total > bulkSyncLimit {
+		return fmt.Errorf("%w: %d rows match, at most %d can", ErrBulkTooLarge, total, bulkSyncLimit)
+	}
+
+	if err := s.store.BulkOperations.Run(ctx, op); err != nil {
+		return err
+	}
+
+	s.changed(ctx, op)
+	return nil
+}
+
+// ProcessPending processes the queued background operations batch by batch
+// until none is left or ctx is done. It returns the number of rows changed.
+// An operation that fails is marked as failed and can be resumed.
+func (s *BulkService) ProcessPending(ctx context.Context) (int64, error) {
+	var processed int64
+
+	for ctx.Err() == nil {
+		id, err := s.store.BulkOperations.GetNextUnfinished(ctx)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return processed, nil
+			}
+			return processed, err
+		}
+
+		for ctx.Err() == nil {
+			op, changed, err := s.store.BulkOperations.ProcessBatch(ctx, id, bulkBatchSize)
+			if err != nil {
+				if ctx.Err() != nil {
+					// interrupted by the shutdown, the operation resumes on the next run
+					return processed, nil
+				}
+
+				s.logger.Errorw("bulk operation failed", "operationID", id, "error", err.Error())
+				if err := s.store.BulkOperations.Fail(ctx, id, err.Error()); err != nil {
+					return processed, err
+				}
+				break
+			}
+
+			processed += changed
+			if changed > 0 {
+				s.changed(ctx, op)
+			}
+
+			if op.Status != store.BulkStatusRunning && op.Status != store.BulkStatusPending {
+				break
+			}
+		}
+	}
+
+	return processed, nil
+}
+
+// Resume queues a failed background operation again
+func (s *BulkService) Resume(ctx context.Context, id int64) error {
+	return s.store.BulkOperations.Resume(ctx, id)
+}
+
+func (s *BulkService) Get(ctx context.Context, id int64) (*store.BulkOperation, error) {
+	return s.store.BulkOperations.GetByID(ctx, id)
+}
+
+func (s *BulkService) List(ctx context.Context, page, limit int) (*BulkOperationsResponse, error) {
+	operations, total, err := s.store.BulkOperations.Get(ctx, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &BulkOperationsResponse{
+		Items:      operations,
+		Total:      total,
+		Page:       page,
+		PageSize:   limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// GetItems lists the rows changed by an operation with their previous values
+func (s *BulkService) GetItems(ctx context.Context, id int64, page, limit int) (*BulkOperationItemsResponse, error) {
+	items, total, err := s.store.BulkOperations.GetItems(ctx, id, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &BulkOperationItemsResponse{
+		Items:      items,
+		Total:      total,
+		Page:       page,
+		PageSize:   limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// changed drops the caches an operation on posts made stale
+func (s *BulkService) changed(ctx context.Context, op *store.BulkOperation) {
+	if op.Target == store.BulkTargetPosts {
+		s.posts.invalidateRelated(ctx)
+	}
+}
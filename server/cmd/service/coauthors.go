@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type CoAuthorService struct {
+	store store.Storage
+}
+
+// Invite adds a pending co-author, who gets their permission on accepting
+func (s *CoAuthorService) Invite(ctx context.Context, coAuthor *store.CoAuthor) error {
+	return s.store.CoAuthors.Invite(ctx, coAuthor)
+}
+
+func (s *CoAuthorService) Accept(ctx context.Context, postID, userID int64) error {
+	return s.store.CoAuthors.Accept(ctx, postID, userID)
+}
+
+func (s *CoAuthorService) UpdatePermission(ctx context.Context, postID, userID int64, permission string) error {
+	return s.store.CoAuthors.UpdatePermission(ctx, postID, userID, permission)
+}
+
+func (s *CoAuthorService) Remove(ctx context.Context, postID, userID int64) error {
+	return s.store.CoAuthors.Remove(ctx, postID, userID)
+}
+
+// GetByPostID returns the co-authors of a post, pending invitations included
+func (s *CoAuthorService) GetByPostID(ctx context.Context, postID int64) ([]store.CoAuthor, error) {
+	return s.store.CoAuthors.GetByPostID(ctx, postID)
+}
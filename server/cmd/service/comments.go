@@ -2,27 +2,157 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/ritchie-gr8/my-blog-app/internal/cursor"
 	"github.com/ritchie-gr8/my-blog-app/internal/store"
 )
 
+var (
+	ErrParentCommentNotFound = errors.New("the comment replied to is not on this post")
+	ErrCommentTooDeep        = fmt.Errorf("replies can be nested at most %d levels deep", store.MaxCommentDepth)
+)
+
 type CommentService struct {
-	store store.Storage
+	store   store.Storage
+	cursors *cursor.Signer
 }
 
-func (s *CommentService) GetByPostID(ctx context.Context, postID int64) ([]store.Comment, error) {
-	comments, err := s.store.Comments.GetByPostID(ctx, postID)
+// CommentsResponse is a page of the comments on a post, each with its
+// replies nested
+type CommentsResponse struct {
+	Items      []store.Comment `json:"items"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+// GetByPostID returns the comments of a post as a tree, the comments on the
+// post newest first with their replies nested oldest first. user_has_liked
+// is set for viewerID, 0 for anonymous readers.
+func (s *CommentService) GetByPostID(ctx context.Context, postID, viewerID int64) ([]store.Comment, error) {
+	comments, err := s.store.Comments.GetByPostID(ctx, postID, viewerID)
 	if err != nil {
 		return nil, err
 	}
 
-	return comments, nil
+	return nestComments(comments), nil
+}
+
+// GetPage returns a page of the comments on a post in the order of sort,
+// with all their replies nested oldest first. An empty cursor starts from
+// the first comment, a cursor of another sort is invalid.
+func (s *CommentService) GetPage(ctx context.Context, postID, viewerID int64, sort, rawCursor string, limit int) (*CommentsResponse, error) {
+	var after *store.CommentCursor
+	if rawCursor != "" {
+		var c store.CommentCursor
+		if err := s.cursors.Decode(rawCursor, &c); err != nil {
+			return nil, err
+		}
+		if c.Sort != sort {
+			return nil, cursor.ErrInvalidCursor
+		}
+		after = &c
+	}
+
+	comments, hasMore, err := s.store.Comments.GetPage(ctx, postID, viewerID, sort, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &CommentsResponse{Items: nestComments(comments)}
+
+	if hasMore {
+		last := res.Items[len(res.Items)-1]
+		next := store.CommentCursor{Sort: sort, ID: last.ID}
+		if sort == store.CommentSortTop {
+			next.Score = last.LikesCount
+		} else {
+			next.CreatedAt = last.CreatedAt
+		}
+
+		res.NextCursor, err = s.cursors.Encode(next)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
 }
 
 func (s *CommentService) Create(ctx context.Context, comment *store.Comment) error {
+	if comment.ParentID != nil {
+		parent, err := s.store.Comments.GetByID(ctx, *comment.ParentID)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return ErrParentCommentNotFound
+			}
+			return err
+		}
+
+		if parent.PostID != comment.PostID || parent.Deleted {
+			return ErrParentCommentNotFound
+		}
+
+		if parent.Depth >= store.MaxCommentDepth {
+			return ErrCommentTooDeep
+		}
+
+		comment.Depth = parent.Depth + 1
+	}
+
 	if err := s.store.Comments.Create(ctx, comment); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+func (s *CommentService) GetByID(ctx context.Context, id int64) (*store.Comment, error) {
+	return s.store.Comments.GetByID(ctx, id)
+}
+
+// Update edits the content of a comment, the previous content is kept in its
+// edit history. Nothing is recorded when the content is unchanged.
+func (s *CommentService) Update(ctx context.Context, comment *store.Comment, content string, editorID int64) error {
+	if comment.Content == content {
+		return nil
+	}
+
+	comment.Content = content
+	return s.store.Comments.Update(ctx, comment, editorID)
+}
+
+// Delete removes a comment, or leaves a tombstone in its place when it has
+// replies. It reports whether a tombstone was left.
+func (s *CommentService) Delete(ctx context.Context, id int64) (bool, error) {
+	return s.store.Comments.Delete(ctx, id)
+}
+
+func (s *CommentService) GetEdits(ctx context.Context, commentID int64) ([]store.CommentEdit, error) {
+	return s.store.Comments.GetEdits(ctx, commentID)
+}
+
+// nestComments turns comments in thread order into a tree, the order of the
+// comments at each level is kept
+func nestComments(comments []store.Comment) []store.Comment {
+	roots := []store.Comment{}
+	replies := map[int64][]store.Comment{}
+
+	for _, c := range comments {
+		if c.ParentID == nil {
+			roots = append(roots, c)
+			continue
+		}
+		replies[*c.ParentID] = append(replies[*c.ParentID], c)
+	}
+
+	var attach func(level []store.Comment) []store.Comment
+	attach = func(level []store.Comment) []store.Comment {
+		for i := range level {
+			level[i].Replies = attach(replies[level[i].ID])
+		}
+		return level
+	}
+
+	return attach(roots)
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type ContentVersionService struct {
+	store store.Storage
+}
+
+// Get returns the version of the content of the site. It changes with every
+// write to the posts, their comments and likes, categories and users, so a
+// response built at a version is still current while it is unchanged.
+func (s *ContentVersionService) Get(ctx context.Context) (int64, error) {
+	return s.store.ContentVersion.Get(ctx)
+}
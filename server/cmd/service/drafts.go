@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type DraftService struct {
+	store store.Storage
+	posts *PostService
+}
+
+func (s *DraftService) Get(ctx context.Context, postID int64) (*store.PostDraft, error) {
+	return s.store.PostDrafts.Get(ctx, postID)
+}
+
+// Save stores edited, a copy of current with the changes of an autosave.
+// Autosaves that change nothing are not written, so clients saving on a
+// timer do not move the revision other editors are based on.
+func (s *DraftService) Save(ctx context.Context, current, edited *store.PostDraft) error {
+	if current.Revision != 0 && sameDraftContent(current, edited) {
+		*edited = *current
+		return nil
+	}
+
+	return s.store.PostDrafts.Save(ctx, edited)
+}
+
+// Publish updates the live post, which the draft at revision was applied to,
+// and discards the draft
+func (s *DraftService) Publish(ctx context.Context, post *store.Post, revision int) error {
+	post.SearchLanguage = s.posts.searchLanguage
+
+	if err := s.store.PostDrafts.Publish(ctx, post, revision); err != nil {
+		return err
+	}
+
+	s.posts.invalidateRelated(ctx)
+	return nil
+}
+
+func (s *DraftService) Discard(ctx context.Context, postID int64) error {
+	return s.store.PostDrafts.Delete(ctx, postID)
+}
+
+func sameDraftContent(a, b *store.PostDraft) bool {
+	sameMedia := (a.ThumbnailMediaID == nil && b.ThumbnailMediaID == nil) ||
+		(a.ThumbnailMediaID != nil && b.ThumbnailMediaID != nil && *a.ThumbnailMediaID == *b.ThumbnailMediaID)
+
+	return a.Title == b.Title && a.Introduction == b.Introduction && a.Content == b.Content &&
+		a.CategoryID == b.CategoryID && a.ThumbnailImage == b.ThumbnailImage && sameMedia &&
+		slices.Equal(a.Tags, b.Tags)
+}
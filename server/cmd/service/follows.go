@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type FollowService struct {
+	store store.Storage
+}
+
+type FollowListResponse struct {
+	Items      []store.FollowUser `json:"items"`
+	Total      int64              `json:"total"`
+	Page       int                `json:"page"`
+	PageSize   int                `json:"page_size"`
+	TotalPages int                `json:"total_pages"`
+}
+
+// Follow makes followerID follow followeeID
+func (s *FollowService) Follow(ctx context.Context, followerID, followeeID int64) error {
+	return s.store.Follows.Follow(ctx, followerID, followeeID)
+}
+
+// Unfollow removes the follow relationship between the two users
+func (s *FollowService) Unfollow(ctx context.Context, followerID, followeeID int64) error {
+	return s.store.Follows.Unfollow(ctx, followerID, followeeID)
+}
+
+// IsFollowing checks if followerID follows followeeID
+func (s *FollowService) IsFollowing(ctx context.Context, followerID, followeeID int64) (bool, error) {
+	return s.store.Follows.IsFollowing(ctx, followerID, followeeID)
+}
+
+// GetCounts returns the number of followers and followed users of a user
+func (s *FollowService) GetCounts(ctx context.Context, userID int64) (*store.FollowCounts, error) {
+	return s.store.Follows.GetCounts(ctx, userID)
+}
+
+func (s *FollowService) GetFollowers(ctx context.Context, userID int64, page, limit int) (*FollowListResponse, error) {
+	users, total, err := s.store.Follows.GetFollowers(ctx, userID, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFollowListResponse(users, total, page, limit), nil
+}
+
+func (s *FollowService) GetFollowing(ctx context.Context, userID int64, page, limit int) (*FollowListResponse, error) {
+	users, total, err := s.store.Follows.GetFollowing(ctx, userID, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFollowListResponse(users, total, page, limit), nil
+}
+
+func newFollowListResponse(users []store.FollowUser, total int64, page, limit int) *FollowListResponse {
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &FollowListResponse{
+		Items:      users,
+		Total:      total,
+		Page:       page,
+		PageSize:   limit,
+		TotalPages: totalPages,
+	}
+}
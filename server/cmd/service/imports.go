@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/markdown"
+)
+
+func (s *PostService) Import(ctx context.Context, files []markdown.File, opts markdown.Options) (*markdown.Report, error) {
+	// imported posts are indexed like the ones created through the API
+	opts.SearchLanguage = s.searchLanguage
+	report, err := markdown.Import(ctx, s.store, files, opts)
+	if err != nil {
+		// the files before the failing one may have been imported
+		s.invalidateRelated(ctx)
+		return nil, err
+	}
+
+	if report.Created > 0 || report.Updated > 0 {
+		s.invalidateRelated(ctx)
+	}
+
+	return report, nil
+}
+
+func (s *PostService) Export(ctx context.Context, userID int64) ([]markdown.File, error) {
+	return markdown.Export(ctx, s.store, userID)
+}
@@ -29,3 +29,18 @@ func (s *PostLikeService) GetLikesCount(ctx context.Context, postID int64) (int6
 func (s *PostLikeService) HasUserLiked(ctx context.Context, postID, userID int64) (bool, error) {
 	return s.store.PostLikes.HasUserLiked(ctx, postID, userID)
 }
+
+type CommentLikeService struct {
+	store store.Storage
+}
+
+// LikeComment adds a like to a comment, liking it twice is a no-op. It
+// reports whether the like is new.
+func (s *CommentLikeService) LikeComment(ctx context.Context, commentID, userID int64) (bool, error) {
+	return s.store.CommentLikes.LikeComment(ctx, commentID, userID)
+}
+
+// UnlikeComment removes a like from a comment
+func (s *CommentLikeService) UnlikeComment(ctx context.Context, commentID, userID int64) error {
+	return s.store.CommentLikes.UnlikeComment(ctx, commentID, userID)
+}
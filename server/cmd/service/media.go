@@ -0,0 +1,108 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ritchie-gr8/my-blog-app/internal/media"
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+	"go.uber.org/zap"
+)
+
+const blobCleanupTimeout = 30 * time.Second
+
+type MediaService struct {
+	store  store.Storage
+	blobs  media.MediaStore
+	limits media.Limits
+	logger *zap.SugaredLogger
+}
+
+// Upload processes an uploaded image, stores its variants and records it.
+// Nothing is kept when any step fails.
+func (s *MediaService) Upload(ctx context.Context, userID int64, data []byte) (*store.Media, error) {
+	img, err := media.ProcessImage(data, s.limits)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := uuid.New().String()
+	record := &store.Media{
+		UserID:      userID,
+		ContentType: img.SourceType,
+		Width:       img.Width,
+		Height:      img.Height,
+		SizeBytes:   int64(len(data)),
+	}
+
+	for _, v := range img.Variants {
+		key := fmt.Sprintf("%s/%s%s", prefix, v.Name, media.Ext(v.ContentType))
+		if err := s.blobs.Put(ctx, key, v.ContentType, bytes.NewReader(v.Data), int64(len(v.Data))); err != nil {
+			s.deleteBlobs(record.Variants)
+			return nil, err
+		}
+
+		record.Variants = append(record.Variants, store.MediaVariant{
+			Name:        v.Name,
+			Key:         key,
+			ContentType: v.ContentType,
+			Width:       v.Width,
+			Height:      v.Height,
+			SizeBytes:   int64(len(v.Data)),
+		})
+	}
+
+	if err := s.store.Media.Create(ctx, record); err != nil {
+		s.deleteBlobs(record.Variants)
+		return nil, err
+	}
+
+	s.setURLs(record)
+	return record, nil
+}
+
+func (s *MediaService) Get(ctx context.Context, id int64) (*store.Media, error) {
+	record, err := s.store.Media.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.setURLs(record)
+	return record, nil
+}
+
+// URL returns the public URL of a variant of the media, falling back to the
+// original when the variant does not exist
+func (s *MediaService) URL(record *store.Media, variant string) string {
+	v := record.Variant(variant)
+	if v == nil {
+		v = record.Variant("original")
+	}
+	if v == nil {
+		return ""
+	}
+
+	return s.blobs.URL(v.Key)
+}
+
+func (s *MediaService) setURLs(record *store.Media) {
+	for i := range record.Variants {
+		record.Variants[i].URL = s.blobs.URL(record.Variants[i].Key)
+	}
+}
+
+// deleteBlobs cleans up after a failed upload. It runs on its own context
+// because the request one may be the reason the upload failed.
+func (s *MediaService) deleteBlobs(variants []store.MediaVariant) {
+	ctx, cancel := context.WithTimeout(context.Background(), blobCleanupTimeout)
+	defer cancel()
+
+	for _, v := range variants {
+		if err := s.blobs.Delete(ctx, v.Key); err != nil {
+			s.logger.Warnw("failed to delete media blob", "key", v.Key, "error", err)
+		}
+	}
+}
@@ -9,10 +9,19 @@ import (
 
 func NewMockService() Service {
 	return Service{
-		Users: &MockUserService{},
+		Users:          &MockUserService{},
+		ContentVersion: &MockContentVersionService{},
 	}
 }
 
+type MockContentVersionService struct {
+	Version int64
+}
+
+func (s *MockContentVersionService) Get(ctx context.Context) (int64, error) {
+	return s.Version, nil
+}
+
 type MockUserService struct {
 }
 
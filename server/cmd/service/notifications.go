@@ -86,6 +86,155 @@ func (s *NotificationService) CreateCommentNotification(ctx context.Context, pos
 	return s.store.Notifications.Create(ctx, notification)
 }
 
+// CreateReplyNotification tells the author of a comment that it was replied
+// to and returns the stored notification, or nil when they replied to
+// themselves
+func (s *NotificationService) CreateReplyNotification(ctx context.Context, reply *store.Comment, actorID int64) (*store.Notification, error) {
+	parent, err := s.store.Comments.GetByID(ctx, *reply.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if parent.UserID == actorID {
+		return nil, nil
+	}
+
+	actor, err := s.store.Users.GetByID(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	notification := &store.Notification{
+		UserID:         parent.UserID,
+		Type:           "reply",
+		RelatedID:      reply.ID,
+		ActorID:        actorID,
+		PostID:         reply.PostID,
+		Message:        fmt.Sprintf("%s replied to your comment", actor.Name),
+		CommentContent: reply.Content,
+		IsRead:         false,
+	}
+
+	if err := s.store.Notifications.Create(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+// CreateCommentLikeNotification tells the author of a comment that it was
+// liked and returns the stored notification, or nil when they liked their
+// own comment
+func (s *NotificationService) CreateCommentLikeNotification(ctx context.Context, comment *store.Comment, actorID int64) (*store.Notification, error) {
+	if comment.UserID == actorID {
+		return nil, nil
+	}
+
+	actor, err := s.store.Users.GetByID(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	notification := &store.Notification{
+		UserID:         comment.UserID,
+		Type:           "comment_like",
+		RelatedID:      comment.ID,
+		ActorID:        actorID,
+		PostID:         comment.PostID,
+		Message:        fmt.Sprintf("%s liked your comment", actor.Name),
+		CommentContent: comment.Content,
+		IsRead:         false,
+	}
+
+	if err := s.store.Notifications.Create(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+// CreateNewPostNotification notifies the followers of an author that they
+// published a post, and returns the IDs of the notified users
+func (s *NotificationService) CreateNewPostNotification(ctx context.Context, postID, actorID int64) ([]int64, error) {
+	// Get actor info for the notification message
+	actor, err := s.store.Users.GetByID(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	notification := &store.Notification{
+		Type:      "new_post",
+		RelatedID: postID,
+		ActorID:   actorID,
+		Message:   fmt.Sprintf("%s published a new post", actor.Name),
+		IsRead:    false,
+	}
+
+	return s.store.Notifications.CreateForFollowers(ctx, notification)
+}
+
+// CreateCoAuthorInviteNotification notifies a user invited to co-author a post
+// and returns the stored notification
+func (s *NotificationService) CreateCoAuthorInviteNotification(ctx context.Context, postID, userID, actorID int64) (*store.Notification, error) {
+	actor, err := s.store.Users.GetByID(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	notification := &store.Notification{
+		UserID:    userID,
+		Type:      "coauthor_invite",
+		RelatedID: postID,
+		ActorID:   actorID,
+		PostID:    postID,
+		Message:   fmt.Sprintf("%s invited you to co-author a post", actor.Name),
+		IsRead:    false,
+	}
+
+	if err := s.store.Notifications.Create(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+// CreateReviewNotification tells the author of a post about a review step
+// and returns the stored notification
+func (s *NotificationService) CreateReviewNotification(ctx context.Context, review *store.Review, authorID int64) (*store.Notification, error) {
+	actor, err := s.store.Users.GetByID(ctx, *review.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var message string
+	switch review.Action {
+	case store.ReviewActionStarted:
+		message = fmt.Sprintf("%s started reviewing your post", actor.Name)
+	case store.ReviewActionApproved:
+		message = fmt.Sprintf("%s approved your post", actor.Name)
+	case store.ReviewActionChangesRequested:
+		message = fmt.Sprintf("%s requested changes to your post", actor.Name)
+	default:
+		message = fmt.Sprintf("%s left a review note on your post", actor.Name)
+	}
+
+	notification := &store.Notification{
+		UserID:    authorID,
+		Type:      "review_" + review.Action,
+		RelatedID: review.PostID,
+		ActorID:   actor.ID,
+		PostID:    review.PostID,
+		Message:   message,
+		IsRead:    false,
+	}
+
+	if err := s.store.Notifications.Create(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
 func (s *NotificationService) GetUserNotifications(ctx context.Context, userID int64, limit, offset int) ([]*store.Notification, error) {
 	return s.store.Notifications.GetByUserID(ctx, userID, limit, offset)
 }
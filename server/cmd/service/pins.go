@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type PinService struct {
+	store store.Storage
+}
+
+func (s *PinService) Pin(ctx context.Context, pin *store.PostPin) error {
+	return s.store.Pins.Pin(ctx, pin)
+}
+
+func (s *PinService) Unpin(ctx context.Context, postID int64) error {
+	return s.store.Pins.Unpin(ctx, postID)
+}
+
+func (s *PinService) Feature(ctx context.Context, feature *store.PostFeature) error {
+	return s.store.Pins.Feature(ctx, feature)
+}
+
+func (s *PinService) Unfeature(ctx context.Context, postID int64) error {
+	return s.store.Pins.Unfeature(ctx, postID)
+}
+
+// GetFeatured returns up to limit featured posts for the homepage carousel
+func (s *PinService) GetFeatured(ctx context.Context, limit int) ([]store.FeaturedPost, error) {
+	return s.store.Pins.GetFeatured(ctx, limit)
+}
@@ -2,27 +2,58 @@ package service
 
 import (
 	"context"
+	"strings"
+	"time"
 
+	"github.com/ritchie-gr8/my-blog-app/internal/cursor"
 	"github.com/ritchie-gr8/my-blog-app/internal/store"
+	"github.com/ritchie-gr8/my-blog-app/internal/store/cache"
+	"go.uber.org/zap"
 )
 
+const relatedCacheSize = 20
+
 type PostService struct {
-	store store.Storage
+	store          store.Storage
+	cacheStore     cache.Storage
+	logger         *zap.SugaredLogger
+	searchLanguage string
+	cursors        *cursor.Signer
+	trashRetention time.Duration
 }
 
+// FeedResponse is shared by both pagination modes. Page based requests fill
+// Page and TotalPages, cursor based requests fill NextCursor and PrevCursor
+// and only report Total when it was asked for.
 type FeedResponse struct {
 	Items      []store.FeedItem `json:"items"`
-	Total      int64            `json:"total"`
+	Total      *int64           `json:"total,omitempty"`
 	Page       int              `json:"page"`
 	PageSize   int              `json:"page_size"`
 	TotalPages int              `json:"total_pages"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+	PrevCursor string           `json:"prev_cursor,omitempty"`
+}
+
+type SearchResponse struct {
+	Items      []store.SearchResult `json:"items"`
+	Total      int64                `json:"total"`
+	Page       int                  `json:"page"`
+	PageSize   int                  `json:"page_size"`
+	TotalPages int                  `json:"total_pages"`
 }
 
 func (s *PostService) Create(ctx context.Context, post *store.Post) error {
+	post.SearchLanguage = s.searchLanguage
+
 	if err := s.store.Posts.Create(ctx, post); err != nil {
 		return err
 	}
 
+	if strings.EqualFold(post.Status, store.PostStatusPublished) {
+		s.invalidateRelated(ctx)
+	}
+
 	return nil
 }
 
@@ -35,36 +66,224 @@ func (s *PostService) Get(ctx context.Context, postID int64, userID int64) (*sto
 	return post, nil
 }
 
-func (s *PostService) Delete(ctx context.Context, postID int64) error {
-	if err := s.store.Posts.Delete(ctx, postID); err != nil {
+func (s *PostService) Delete(ctx context.Context, postID int64, version int) error {
+	if err := s.store.Posts.Delete(ctx, postID, version); err != nil {
 		return err
 	}
+
+	s.invalidateRelated(ctx)
 	return nil
 }
 
 func (s *PostService) Update(ctx context.Context, post *store.Post) error {
+	post.SearchLanguage = s.searchLanguage
+
 	if err := s.store.Posts.Update(ctx, post); err != nil {
 		return err
 	}
+
+	s.invalidateRelated(ctx)
 	return nil
 }
 
 func (s *PostService) GetFeed(ctx context.Context, fq store.PaginatedFeedQuery) (*FeedResponse, error) {
+	// the pinned posts are listed first on the first page and left out of
+	// the pages that follow
+	var pinned []store.FeedItem
+	if fq.ShowsPinned() {
+		fq.SeparatePinned = true
+
+		if !fq.UseCursor || fq.Cursor == "" {
+			var err error
+			if pinned, err = s.store.Posts.GetPinned(ctx, fq); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if fq.UseCursor {
+		response, err := s.getFeedByCursor(ctx, fq)
+		if err != nil {
+			return nil, err
+		}
+
+		if fq.Cursor == "" {
+			response.Items = append(pinned, response.Items...)
+		}
+		return response, nil
+	}
+
 	feed, total, err := s.store.Posts.GetFeed(ctx, fq)
 	if err != nil {
 		return nil, err
 	}
 
-	totalPages := int(total) / fq.Limit
-	if int(total)%fq.Limit > 0 {
+	// the total counts the pinned posts, the pages only hold the others
+	unpinned := int(total) - len(pinned)
+	totalPages := unpinned / fq.Limit
+	if unpinned%fq.Limit > 0 || (totalPages == 0 && len(pinned) > 0) {
 		totalPages++
 	}
 
+	if fq.Page == 1 {
+		feed = append(pinned, feed...)
+	}
+
 	return &FeedResponse{
 		Items:      feed,
-		Total:      total,
+		Total:      &total,
 		Page:       fq.Page,
 		PageSize:   fq.Limit,
 		TotalPages: totalPages,
 	}, nil
 }
+
+// GetFollowingFeed returns the published posts of the authors userID follows,
+// always using cursor pagination
+func (s *PostService) GetFollowingFeed(ctx context.Context, fq store.PaginatedFeedQuery, userID int64) (*FeedResponse, error) {
+	fq.FollowerID = userID
+	fq.Status = store.PostStatusPublished
+	fq.UseCursor = true
+
+	return s.getFeedByCursor(ctx, fq)
+}
+
+func (s *PostService) getFeedByCursor(ctx context.Context, fq store.PaginatedFeedQuery) (*FeedResponse, error) {
+	var current *store.FeedCursor
+	if fq.Cursor != "" {
+		current = &store.FeedCursor{}
+		if err := s.cursors.Decode(fq.Cursor, current); err != nil {
+			return nil, err
+		}
+
+		// a cursor only makes sense for the ordering it was created with
+		if current.Sort != fq.Sort || current.Window != fq.Window {
+			return nil, cursor.ErrInvalidCursor
+		}
+	}
+
+	feed, hasMore, err := s.store.Posts.GetFeedByCursor(ctx, fq, current)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &FeedResponse{
+		Items:    feed,
+		PageSize: fq.Limit,
+	}
+
+	if len(feed) > 0 {
+		backward := current != nil && current.Backward
+
+		if hasMore || backward {
+			next := store.NewFeedCursor(fq, feed[len(feed)-1], false)
+			if response.NextCursor, err = s.cursors.Encode(next); err != nil {
+				return nil, err
+			}
+		}
+
+		if (backward && hasMore) || (!backward && current != nil) {
+			prev := store.NewFeedCursor(fq, feed[0], true)
+			if response.PrevCursor, err = s.cursors.Encode(prev); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if fq.IncludeTotal {
+		total, err := s.getFeedTotal(ctx, fq)
+		if err != nil {
+			return nil, err
+		}
+		response.Total = &total
+	}
+
+	return response, nil
+}
+
+// getFeedTotal counts the posts matching the feed filters. The count is
+// cached for a short time since it is the expensive part of deep pagination.
+func (s *PostService) getFeedTotal(ctx context.Context, fq store.PaginatedFeedQuery) (int64, error) {
+	cached, err := s.cacheStore.Feed.GetTotal(ctx, fq)
+	if err != nil && err != cache.ErrRedisNotInit {
+		s.logger.Warnw("error getting cached feed total", "error", err)
+	}
+
+	if cached != nil {
+		return *cached, nil
+	}
+
+	total, err := s.store.Posts.CountFeed(ctx, fq)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.cacheStore.Feed.SetTotal(ctx, fq, total); err != nil && err != cache.ErrRedisNotInit {
+		s.logger.Warnw("error caching feed total", "error", err)
+	}
+
+	return total, nil
+}
+
+func (s *PostService) Search(ctx context.Context, sq store.PaginatedSearchQuery) (*SearchResponse, error) {
+	if sq.Language == "" {
+		sq.Language = s.searchLanguage
+	}
+
+	results, total, err := s.store.Posts.Search(ctx, sq)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / sq.Limit
+	if int(total)%sq.Limit > 0 {
+		totalPages++
+	}
+
+	return &SearchResponse{
+		Items:      results,
+		Total:      total,
+		Page:       sq.Page,
+		PageSize:   sq.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// GetRelated returns up to limit posts related to a post. The ranking is
+// computed once for relatedCacheSize posts and cached, so any limit up to that
+// size is served from the same entry.
+func (s *PostService) GetRelated(ctx context.Context, postID int64, limit int) ([]store.RelatedPost, error) {
+	posts, version, err := s.cacheStore.Related.Get(ctx, postID)
+	if err != nil && err != cache.ErrRedisNotInit {
+		s.logger.Warnw("error getting cached related posts", "error", err, "postID", postID)
+	}
+
+	if posts == nil {
+		posts, err = s.store.Posts.GetRelated(ctx, postID, relatedCacheSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.cacheStore.Related.Set(ctx, postID, version, posts); err != nil && err != cache.ErrRedisNotInit {
+			s.logger.Warnw("error caching related posts", "error", err, "postID", postID)
+		}
+	}
+
+	if len(posts) > limit {
+		posts = posts[:limit]
+	}
+
+	return posts, nil
+}
+
+// invalidateRelated drops every cached related posts list, a changed post may
+// appear in any of them
+func (s *PostService) invalidateRelated(ctx context.Context) {
+	if err := s.cacheStore.Related.Invalidate(ctx); err != nil && err != cache.ErrRedisNotInit {
+		s.logger.Warnw("error invalidating related posts cache", "error", err)
+	}
+}
+
+func (s *PostService) RefreshTrendingScores(ctx context.Context) (int64, error) {
+	return s.store.Posts.RefreshTrendingScores(ctx)
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type PreviewService struct {
+	store store.Storage
+}
+
+// CreateToken stores a new preview token for a post. Only the hash is kept,
+// so the plain token has to be handed back to the caller right away.
+func (s *PreviewService) CreateToken(ctx context.Context, token *store.PreviewToken, hashToken string, exp time.Duration) error {
+	return s.store.PreviewTokens.Create(ctx, token, hashToken, exp)
+}
+
+func (s *PreviewService) GetTokens(ctx context.Context, postID int64) ([]store.PreviewToken, error) {
+	return s.store.PreviewTokens.GetByPostID(ctx, postID)
+}
+
+func (s *PreviewService) RevokeToken(ctx context.Context, postID, tokenID int64) error {
+	return s.store.PreviewTokens.Revoke(ctx, postID, tokenID)
+}
+
+// GetPost returns the post a valid preview token points to, whatever its status
+func (s *PreviewService) GetPost(ctx context.Context, hashToken string) (*store.Post, error) {
+	postID, err := s.store.PreviewTokens.GetPostID(ctx, hashToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.Posts.GetByID(ctx, postID, 0)
+}
+
+func (s *PreviewService) DeleteExpiredTokens(ctx context.Context) (int64, error) {
+	return s.store.PreviewTokens.DeleteExpired(ctx)
+}
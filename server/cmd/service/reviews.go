@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type ReviewService struct {
+	store store.Storage
+}
+
+type ReviewQueueResponse struct {
+	Items      []store.ReviewQueueItem `json:"items"`
+	Total      int64                   `json:"total"`
+	Page       int                     `json:"page"`
+	PageSize   int                     `json:"page_size"`
+	TotalPages int                     `json:"total_pages"`
+}
+
+// reviewSteps lists, for each moderator action, the statuses it applies to
+// and the status it leads to. Comments leave the status alone.
+var reviewSteps = map[string]struct {
+	from []string
+	to   string
+}{
+	store.ReviewActionStarted: {
+		from: []string{store.PostStatusSubmitted},
+		to:   store.PostStatusInReview,
+	},
+	store.ReviewActionApproved: {
+		from: []string{store.PostStatusSubmitted, store.PostStatusInReview},
+		to:   store.PostStatusApproved,
+	},
+	store.ReviewActionChangesRequested: {
+		from: []string{store.PostStatusSubmitted, store.PostStatusInReview, store.PostStatusApproved},
+		to:   store.PostStatusChangesRequested,
+	},
+}
+
+// CheckEdit settles the status an edit leaves post in, see
+// store.StatusAfterEdit, and checks that the edit may move post there from
+// the status from. The category of the post decides whether publishing
+// requires an approval.
+func (s *ReviewService) CheckEdit(ctx context.Context, post *store.Post, from string, contentChanged, isModerator bool) error {
+	category, err := s.store.Categories.GetByID(ctx, post.CategoryID)
+	if err != nil {
+		return err
+	}
+
+	post.Status = store.StatusAfterEdit(from, post.Status, contentChanged, category.RequiresReview, isModerator)
+	return store.CheckStatusTransition(from, post.Status, contentChanged, category.RequiresReview, isModerator)
+}
+
+// RecordStatusChange adds the history entry of a status changed by an edit,
+// if the change is part of the review workflow
+func (s *ReviewService) RecordStatusChange(ctx context.Context, postID, userID int64, from, to string) error {
+	action := store.ReviewActionForStatusChange(from, to)
+	if action == "" {
+		return nil
+	}
+
+	return s.store.Reviews.Create(ctx, &store.Review{
+		PostID:     postID,
+		UserID:     &userID,
+		Action:     action,
+		FromStatus: from,
+		ToStatus:   to,
+	})
+}
+
+// Review applies a moderator action to a post. Comments only add a note,
+// the other actions move the post along the workflow.
+func (s *ReviewService) Review(ctx context.Context, post *store.Post, reviewerID int64, action, note string) (*store.Review, error) {
+	review := &store.Review{
+		PostID: post.ID,
+		UserID: &reviewerID,
+		Action: action,
+		Note:   note,
+	}
+
+	if action == store.ReviewActionComment {
+		review.FromStatus = post.Status
+		review.ToStatus = post.Status
+		if err := s.store.Reviews.Create(ctx, review); err != nil {
+			return nil, err
+		}
+		return review, nil
+	}
+
+	step, ok := reviewSteps[action]
+	if !ok {
+		return nil, fmt.Errorf("unknown review action %q", action)
+	}
+
+	review.ToStatus = step.to
+	if err := s.store.Reviews.Transition(ctx, review, step.from); err != nil {
+		return nil, err
+	}
+
+	post.Status = step.to
+	return review, nil
+}
+
+func (s *ReviewService) GetHistory(ctx context.Context, postID int64) ([]store.Review, error) {
+	return s.store.Reviews.GetByPostID(ctx, postID)
+}
+
+// GetQueue returns the posts waiting for a review, an empty status lists
+// both submitted posts and posts in review
+func (s *ReviewService) GetQueue(ctx context.Context, status string, page, limit int) (*ReviewQueueResponse, error) {
+	statuses := []string{store.PostStatusSubmitted, store.PostStatusInReview}
+	if status != "" {
+		statuses = []string{status}
+	}
+
+	items, total, err := s.store.Reviews.GetQueue(ctx, statuses, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &ReviewQueueResponse{
+		Items:      items,
+		Total:      total,
+		Page:       page,
+		PageSize:   limit,
+		TotalPages: totalPages,
+	}, nil
+}
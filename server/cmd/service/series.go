@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/cursor"
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type SeriesService struct {
+	store   store.Storage
+	cursors *cursor.Signer
+}
+
+// SeriesResponse is the series landing page, its posts are paginated with an
+// opaque cursor
+type SeriesResponse struct {
+	Series     *store.Series      `json:"series"`
+	Items      []store.SeriesPost `json:"items"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+func (s *SeriesService) Create(ctx context.Context, series *store.Series) error {
+	return s.store.Series.Create(ctx, series)
+}
+
+func (s *SeriesService) Get(ctx context.Context, id int64) (*store.Series, error) {
+	return s.store.Series.GetByID(ctx, id)
+}
+
+func (s *SeriesService) Update(ctx context.Context, series *store.Series) error {
+	return s.store.Series.Update(ctx, series)
+}
+
+func (s *SeriesService) Delete(ctx context.Context, id int64) error {
+	return s.store.Series.Delete(ctx, id)
+}
+
+func (s *SeriesService) AddPost(ctx context.Context, seriesID, postID int64) (int, error) {
+	return s.store.Series.AddPost(ctx, seriesID, postID)
+}
+
+func (s *SeriesService) RemovePost(ctx context.Context, seriesID, postID int64) error {
+	return s.store.Series.RemovePost(ctx, seriesID, postID)
+}
+
+func (s *SeriesService) Reorder(ctx context.Context, seriesID int64, postIDs []int64) error {
+	return s.store.Series.Reorder(ctx, seriesID, postIDs)
+}
+
+// GetNavigation returns where a post sits in its series, or nil when the post
+// is not part of any series
+func (s *SeriesService) GetNavigation(ctx context.Context, postID int64, includeDrafts bool) (*store.SeriesNavigation, error) {
+	nav, err := s.store.Series.GetNavigation(ctx, postID, includeDrafts)
+	if err == store.ErrNotFound {
+		return nil, nil
+	}
+
+	return nav, err
+}
+
+// GetPage returns a series with one page of its posts. An empty cursor starts
+// from the first part.
+func (s *SeriesService) GetPage(ctx context.Context, series *store.Series, rawCursor string, limit int, includeDrafts bool) (*SeriesResponse, error) {
+	var after int
+	if rawCursor != "" {
+		var c store.SeriesCursor
+		if err := s.cursors.Decode(rawCursor, &c); err != nil {
+			return nil, err
+		}
+
+		if c.SeriesID != series.ID {
+			return nil, cursor.ErrInvalidCursor
+		}
+
+		after = c.Position
+	}
+
+	posts, hasMore, err := s.store.Series.GetPosts(ctx, series.ID, after, limit, includeDrafts)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &SeriesResponse{
+		Series: series,
+		Items:  posts,
+	}
+
+	if hasMore {
+		last := posts[len(posts)-1]
+		res.NextCursor, err = s.cursors.Encode(store.SeriesCursor{SeriesID: series.ID, Position: last.Position})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
@@ -2,12 +2,17 @@ package service
 
 import (
 	"context"
+	"strings"
 	"time"
 
+	"github.com/ritchie-gr8/my-blog-app/internal/cursor"
 	"github.com/ritchie-gr8/my-blog-app/internal/mailer"
+	"github.com/ritchie-gr8/my-blog-app/internal/markdown"
+	"github.com/ritchie-gr8/my-blog-app/internal/media"
 	"github.com/ritchie-gr8/my-blog-app/internal/store"
 	"github.com/ritchie-gr8/my-blog-app/internal/store/cache"
 	"go.uber.org/zap"
+	"golang.org/x/text/language"
 )
 
 type emailConfig struct {
@@ -24,6 +29,72 @@ func NewEmailConfig(env, frontendURL string, mailer mailer.Client) *emailConfig
 	}
 }
 
+type searchConfig struct {
+	language string
+}
+
+func NewSearchConfig(language string) *searchConfig {
+	return &searchConfig{
+		language: language,
+	}
+}
+
+type feedConfig struct {
+	cursorSecret string
+}
+
+func NewFeedConfig(cursorSecret string) *feedConfig {
+	return &feedConfig{
+		cursorSecret: cursorSecret,
+	}
+}
+
+type trashConfig struct {
+	retention time.Duration
+}
+
+func NewTrashConfig(retention time.Duration) *trashConfig {
+	return &trashConfig{
+		retention: retention,
+	}
+}
+
+type mediaConfig struct {
+	blobs  media.MediaStore
+	limits media.Limits
+}
+
+func NewMediaConfig(blobs media.MediaStore, limits media.Limits) *mediaConfig {
+	return &mediaConfig{
+		blobs:  blobs,
+		limits: limits,
+	}
+}
+
+type viewsConfig struct {
+	window            time.Duration
+	fingerprintSecret string
+}
+
+func NewViewsConfig(window time.Duration, fingerprintSecret string) *viewsConfig {
+	return &viewsConfig{
+		window:            window,
+		fingerprintSecret: fingerprintSecret,
+	}
+}
+
+type localesConfig struct {
+	supported []string
+}
+
+// NewLocalesConfig takes the comma separated locales posts can be written
+// in, the first one is the default of the site
+func NewLocalesConfig(supported string) *localesConfig {
+	return &localesConfig{
+		supported: strings.Split(supported, ","),
+	}
+}
+
 type Service struct {
 	Users interface {
 		Get(ctx context.Context, id int64) (*store.User, error)
@@ -38,14 +109,38 @@ type Service struct {
 	Posts interface {
 		Create(ctx context.Context, post *store.Post) error
 		Get(ctx context.Context, postID int64, userID int64) (*store.Post, error)
-		Delete(ctx context.Context, postID int64) error
+		Delete(ctx context.Context, postID int64, version int) error
 		Update(ctx context.Context, post *store.Post) error
 		GetFeed(context.Context, store.PaginatedFeedQuery) (*FeedResponse, error)
+		GetFollowingFeed(ctx context.Context, fq store.PaginatedFeedQuery, userID int64) (*FeedResponse, error)
+		Search(context.Context, store.PaginatedSearchQuery) (*SearchResponse, error)
+		GetRelated(ctx context.Context, postID int64, limit int) ([]store.RelatedPost, error)
+		GetArchive(context.Context, store.ArchiveQuery) (*ArchiveResponse, error)
+		Import(ctx context.Context, files []markdown.File, opts markdown.Options) (*markdown.Report, error)
+		Export(ctx context.Context, userID int64) ([]markdown.File, error)
+		RefreshTrendingScores(ctx context.Context) (int64, error)
+		GetTrash(ctx context.Context, userID int64, page, limit int) (*TrashResponse, error)
+		GetTrashed(ctx context.Context, postID int64) (*store.TrashedPost, error)
+		Restore(ctx context.Context, postID int64) error
+		Purge(ctx context.Context, postID int64) error
+		PurgeExpiredTrash(ctx context.Context) (int64, error)
+	}
+
+	Drafts interface {
+		Get(ctx context.Context, postID int64) (*store.PostDraft, error)
+		Save(ctx context.Context, current, edited *store.PostDraft) error
+		Publish(ctx context.Context, post *store.Post, revision int) error
+		Discard(ctx context.Context, postID int64) error
 	}
 
 	Comments interface {
-		GetByPostID(ctx context.Context, postID int64) ([]store.Comment, error)
+		GetByPostID(ctx context.Context, postID, viewerID int64) ([]store.Comment, error)
+		GetPage(ctx context.Context, postID, viewerID int64, sort, cursor string, limit int) (*CommentsResponse, error)
+		GetByID(ctx context.Context, id int64) (*store.Comment, error)
 		Create(ctx context.Context, comment *store.Comment) error
+		Update(ctx context.Context, comment *store.Comment, content string, editorID int64) error
+		Delete(ctx context.Context, id int64) (bool, error)
+		GetEdits(ctx context.Context, commentID int64) ([]store.CommentEdit, error)
 	}
 
 	Emails interface {
@@ -55,12 +150,15 @@ type Service struct {
 
 	Tokens interface {
 		GenerateActivationToken() (plainToken string, hashedToken string)
+		GeneratePreviewToken() (plainToken string, hashedToken string)
+		HashToken(plainToken string) string
 	}
 
 	Categories interface {
 		Create(ctx context.Context, category *store.Category) error
 		GetAll(ctx context.Context) ([]*store.Category, error)
 		Get(ctx context.Context, query *store.PaginatedCategoryQuery) (*PaginatedCategoryResponse, error)
+		GetByID(ctx context.Context, id int64) (*store.Category, error)
 		Delete(ctx context.Context, id int64) error
 		Update(ctx context.Context, category *store.Category) error
 	}
@@ -72,31 +170,165 @@ type Service struct {
 		HasUserLiked(ctx context.Context, postID, userID int64) (bool, error)
 	}
 
+	CommentLikes interface {
+		LikeComment(ctx context.Context, commentID, userID int64) (bool, error)
+		UnlikeComment(ctx context.Context, commentID, userID int64) error
+	}
+
 	Notifications interface {
 		CreateNotification(ctx context.Context, notification *store.Notification) error
 		CreateLikeNotification(ctx context.Context, postID, actorID int64) error
 		CreateCommentNotification(ctx context.Context, postID, commentID, actorID int64) error
+		CreateReplyNotification(ctx context.Context, reply *store.Comment, actorID int64) (*store.Notification, error)
+		CreateCommentLikeNotification(ctx context.Context, comment *store.Comment, actorID int64) (*store.Notification, error)
+		CreateNewPostNotification(ctx context.Context, postID, actorID int64) ([]int64, error)
+		CreateCoAuthorInviteNotification(ctx context.Context, postID, userID, actorID int64) (*store.Notification, error)
+		CreateReviewNotification(ctx context.Context, review *store.Review, authorID int64) (*store.Notification, error)
 		GetUserNotifications(ctx context.Context, userID int64, limit, offset int) ([]*store.Notification, error)
 		GetNotification(ctx context.Context, userID int64, page, limit int) (*NotificationResponse, error)
 		CountUnreadNotifications(ctx context.Context, userID int64) (int64, error)
 		MarkNotificationAsRead(ctx context.Context, id int64, userID int64) error
 		MarkAllNotificationsAsRead(ctx context.Context, userID int64) error
 	}
+
+	Follows interface {
+		Follow(ctx context.Context, followerID, followeeID int64) error
+		Unfollow(ctx context.Context, followerID, followeeID int64) error
+		IsFollowing(ctx context.Context, followerID, followeeID int64) (bool, error)
+		GetCounts(ctx context.Context, userID int64) (*store.FollowCounts, error)
+		GetFollowers(ctx context.Context, userID int64, page, limit int) (*FollowListResponse, error)
+		GetFollowing(ctx context.Context, userID int64, page, limit int) (*FollowListResponse, error)
+	}
+
+	Series interface {
+		Create(ctx context.Context, series *store.Series) error
+		Get(ctx context.Context, id int64) (*store.Series, error)
+		Update(ctx context.Context, series *store.Series) error
+		Delete(ctx context.Context, id int64) error
+		AddPost(ctx context.Context, seriesID, postID int64) (int, error)
+		RemovePost(ctx context.Context, seriesID, postID int64) error
+		Reorder(ctx context.Context, seriesID int64, postIDs []int64) error
+		GetNavigation(ctx context.Context, postID int64, includeDrafts bool) (*store.SeriesNavigation, error)
+		GetPage(ctx context.Context, series *store.Series, cursor string, limit int, includeDrafts bool) (*SeriesResponse, error)
+	}
+
+	CoAuthors interface {
+		Invite(ctx context.Context, coAuthor *store.CoAuthor) error
+		Accept(ctx context.Context, postID, userID int64) error
+		UpdatePermission(ctx context.Context, postID, userID int64, permission string) error
+		Remove(ctx context.Context, postID, userID int64) error
+		GetByPostID(ctx context.Context, postID int64) ([]store.CoAuthor, error)
+	}
+
+	Reviews interface {
+		CheckEdit(ctx context.Context, post *store.Post, from string, contentChanged, isModerator bool) error
+		RecordStatusChange(ctx context.Context, postID, userID int64, from, to string) error
+		Review(ctx context.Context, post *store.Post, reviewerID int64, action, note string) (*store.Review, error)
+		GetHistory(ctx context.Context, postID int64) ([]store.Review, error)
+		GetQueue(ctx context.Context, status string, page, limit int) (*ReviewQueueResponse, error)
+	}
+
+	Bookmarks interface {
+		Bookmark(ctx context.Context, postID, userID int64) error
+		Unbookmark(ctx context.Context, postID, userID int64) error
+		HasUserBookmarked(ctx context.Context, postID, userID int64) (bool, error)
+		GetBookmarks(ctx context.Context, userID int64, cursor string, limit int) (*BookmarksResponse, error)
+	}
+
+	ReadingLists interface {
+		Create(ctx context.Context, list *store.ReadingList) error
+		Get(ctx context.Context, id int64) (*store.ReadingList, error)
+		GetByUserID(ctx context.Context, userID int64) ([]store.ReadingList, error)
+		Update(ctx context.Context, list *store.ReadingList) error
+		Delete(ctx context.Context, id int64) error
+		AddPost(ctx context.Context, listID, postID int64) (int, error)
+		RemovePost(ctx context.Context, listID, postID int64) error
+		Reorder(ctx context.Context, listID int64, postIDs []int64) error
+		GetPage(ctx context.Context, list *store.ReadingList, cursor string, limit int) (*ReadingListResponse, error)
+	}
+
+	Media interface {
+		Upload(ctx context.Context, userID int64, data []byte) (*store.Media, error)
+		Get(ctx context.Context, id int64) (*store.Media, error)
+		URL(media *store.Media, variant string) string
+	}
+
+	Previews interface {
+		CreateToken(ctx context.Context, token *store.PreviewToken, hashToken string, exp time.Duration) error
+		GetTokens(ctx context.Context, postID int64) ([]store.PreviewToken, error)
+		RevokeToken(ctx context.Context, postID, tokenID int64) error
+		GetPost(ctx context.Context, hashToken string) (*store.Post, error)
+		DeleteExpiredTokens(ctx context.Context) (int64, error)
+	}
+
+	Translations interface {
+		DefaultLocale() string
+		ParseLocale(locale string) (string, error)
+		Localize(ctx context.Context, post *store.Post, preferred []language.Tag) error
+		LocalizeFeed(ctx context.Context, items []store.FeedItem, locale string) error
+		GetByPostID(ctx context.Context, postID int64) ([]store.Translation, error)
+		Put(ctx context.Context, post *store.Post, translation *store.Translation) (bool, error)
+		Delete(ctx context.Context, postID int64, locale string) error
+		GetPostIDBySlug(ctx context.Context, locale, slug string) (int64, error)
+	}
+
+	Pins interface {
+		Pin(ctx context.Context, pin *store.PostPin) error
+		Unpin(ctx context.Context, postID int64) error
+		Feature(ctx context.Context, feature *store.PostFeature) error
+		Unfeature(ctx context.Context, postID int64) error
+		GetFeatured(ctx context.Context, limit int) ([]store.FeaturedPost, error)
+	}
+
+	BulkOperations interface {
+		DryRun(ctx context.Context, op *store.BulkOperation) (*BulkDryRunResponse, error)
+		Start(ctx context.Context, op *store.BulkOperation) error
+		ProcessPending(ctx context.Context) (int64, error)
+		Resume(ctx context.Context, id int64) error
+		Get(ctx context.Context, id int64) (*store.BulkOperation, error)
+		List(ctx context.Context, page, limit int) (*BulkOperationsResponse, error)
+		GetItems(ctx context.Context, id int64, page, limit int) (*BulkOperationItemsResponse, error)
+	}
+
+	Views interface {
+		Record(ctx context.Context, postID int64, viewer string)
+		ViewerFingerprint(ip, userAgent string) string
+		UserViewer(userID int64) string
+		Flush(ctx context.Context) (int64, error)
+		GetAnalytics(ctx context.Context, userID int64, from, to time.Time) (*AnalyticsResponse, error)
+	}
+
+	ContentVersion interface {
+		Get(ctx context.Context) (int64, error)
+	}
 }
 
 func NewService(store store.Storage, cacheStore cache.Storage,
-	logger *zap.SugaredLogger, emailConfig emailConfig) Service {
+	logger *zap.SugaredLogger, emailConfig emailConfig, searchConfig searchConfig, feedConfig feedConfig,
+	trashConfig trashConfig, mediaConfig mediaConfig, viewsConfig viewsConfig, localesConfig localesConfig) Service {
+	posts := &PostService{
+		store:          store,
+		cacheStore:     cacheStore,
+		logger:         logger,
+		searchLanguage: searchConfig.language,
+		cursors:        cursor.NewSigner(feedConfig.cursorSecret),
+		trashRetention: trashConfig.retention,
+	}
+
 	return Service{
 		Users: &UserService{
 			store:      store,
 			cacheStore: cacheStore,
 			logger:     logger,
 		},
-		Posts: &PostService{
+		Posts: posts,
+		Drafts: &DraftService{
 			store: store,
+			posts: posts,
 		},
 		Comments: &CommentService{
-			store: store,
+			store:   store,
+			cursors: cursor.NewSigner(feedConfig.cursorSecret),
 		},
 		Emails: &EmailService{
 			mailer:      emailConfig.mailer,
@@ -112,8 +344,61 @@ func NewService(store store.Storage, cacheStore cache.Storage,
 		PostLikes: &PostLikeService{
 			store: store,
 		},
+		CommentLikes: &CommentLikeService{
+			store: store,
+		},
 		Notifications: &NotificationService{
 			store: store,
 		},
+		Follows: &FollowService{
+			store: store,
+		},
+		Previews: &PreviewService{
+			store: store,
+		},
+		Series: &SeriesService{
+			store:   store,
+			cursors: cursor.NewSigner(feedConfig.cursorSecret),
+		},
+		CoAuthors: &CoAuthorService{
+			store: store,
+		},
+		Reviews: &ReviewService{
+			store: store,
+		},
+		Bookmarks: &BookmarkService{
+			store:   store,
+			cursors: cursor.NewSigner(feedConfig.cursorSecret),
+		},
+		ReadingLists: &ReadingListService{
+			store:   store,
+			cursors: cursor.NewSigner(feedConfig.cursorSecret),
+		},
+		Media: &MediaService{
+			store:  store,
+			blobs:  mediaConfig.blobs,
+			limits: mediaConfig.limits,
+			logger: logger,
+		},
+		Translations: newTranslationService(store, localesConfig),
+		Pins: &PinService{
+			store: store,
+		},
+		BulkOperations: &BulkService{
+			store:  store,
+			posts:  posts,
+			logger: logger,
+		},
+		Views: &ViewService{
+			store:      store,
+			cacheStore: cacheStore,
+			logger:     logger,
+			window:     viewsConfig.window,
+			secret:     []byte(viewsConfig.fingerprintSecret),
+			local:      newViewBuffer(),
+		},
+		ContentVersion: &ContentVersionService{
+			store: store,
+		},
 	}
 }
@@ -13,8 +13,24 @@ type TokenService struct {
 }
 
 func (th *TokenService) GenerateActivationToken() (plainToken string, hashedToken string) {
+	return generateToken()
+}
+
+func (th *TokenService) GeneratePreviewToken() (plainToken string, hashedToken string) {
+	return generateToken()
+}
+
+// HashToken hashes a plain token the same way generated tokens are stored
+func (th *TokenService) HashToken(plainToken string) string {
+	return hashToken(plainToken)
+}
+
+func generateToken() (plainToken string, hashedToken string) {
 	plainToken = uuid.New().String()
+	return plainToken, hashToken(plainToken)
+}
+
+func hashToken(plainToken string) string {
 	hash := sha256.Sum256([]byte(plainToken))
-	hashedToken = hex.EncodeToString(hash[:])
-	return plainToken, hashedToken
+	return hex.EncodeToString(hash[:])
 }
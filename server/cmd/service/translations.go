@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+	"golang.org/x/text/language"
+)
+
+// alternateURLFormat is the path of a post in a given language
+const alternateURLFormat = "/v1/posts/%d?lang=%s"
+
+var (
+	ErrUnsupportedLocale = errors.New("unsupported locale")
+	ErrOriginalLocale    = errors.New("the post is written in this locale, update the post instead")
+	ErrInvalidSlug       = errors.New("slug must contain at least one letter or digit")
+)
+
+type TranslationService struct {
+	store store.Storage
+	// locales are the languages posts can be written in, the first one is
+	// the default of the site
+	locales []language.Tag
+	matcher language.Matcher
+}
+
+func newTranslationService(store store.Storage, localesConfig localesConfig) *TranslationService {
+	locales := make([]language.Tag, 0, len(localesConfig.supported))
+	for _, locale := range localesConfig.supported {
+		if tag, err := language.Parse(strings.TrimSpace(locale)); err == nil {
+			locales = append(locales, tag)
+		}
+	}
+
+	if len(locales) == 0 {
+		locales = []language.Tag{language.English}
+	}
+
+	return &TranslationService{
+		store:   store,
+		locales: locales,
+		matcher: language.NewMatcher(locales),
+	}
+}
+
+// DefaultLocale returns the language of the site
+func (s *TranslationService) DefaultLocale() string {
+	return s.locales[0].String()
+}
+
+// ParseLocale returns the supported locale closest to a BCP 47 tag, so th-TH
+// is read as th. It returns ErrUnsupportedLocale when none is close enough.
+func (s *TranslationService) ParseLocale(locale string) (string, error) {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedLocale, locale)
+	}
+
+	_, index, confidence := s.matcher.Match(tag)
+	if confidence < language.High {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedLocale, locale)
+	}
+
+	return s.locales[index].String(), nil
+}
+
+// Localize replaces the content of a post with its translation in the best
+// match for the preferred languages and lists the other languages it can be
+// read in. When no translation matches, the site locale is tried before the
+// post is left in its original language.
+func (s *TranslationService) Localize(ctx context.Context, post *store.Post, preferred []language.Tag) error {
+	translations, err := s.store.Translations.GetByPostID(ctx, post.ID)
+	if err != nil {
+		return err
+	}
+
+	post.Alternates = []store.Alternate{{
+		Locale:   post.Locale,
+		Title:    post.Title,
+		URL:      fmt.Sprintf(alternateURLFormat, post.ID, post.Locale),
+		Original: true,
+	}}
+	for _, t := range translations {
+		post.Alternates = append(post.Alternates, store.Alternate{
+			Locale: t.Locale,
+			Title:  t.Title,
+			Slug:   t.Slug,
+			URL:    fmt.Sprintf(alternateURLFormat, post.ID, t.Locale),
+		})
+	}
+
+	if len(translations) == 0 {
+		return nil
+	}
+
+	// the original comes first, the matcher falls back to it
+	tags := make([]language.Tag, 0, len(post.Alternates))
+	for _, alternate := range post.Alternates {
+		tags = append(tags, language.Make(alternate.Locale))
+	}
+	matcher := language.NewMatcher(tags)
+
+	_, index, confidence := matcher.Match(preferred...)
+	if confidence == language.No {
+		_, index, _ = matcher.Match(s.locales[0])
+	}
+
+	if index > 0 {
+		t := translations[index-1]
+		post.Title = t.Title
+		post.Introduction = t.Introduction
+		post.Content = t.Content
+		post.Slug = t.Slug
+		post.Locale = t.Locale
+	}
+
+	return nil
+}
+
+// LocalizeFeed shows the items of a feed filtered by locale in that locale.
+// Items written in it are left as they are.
+func (s *TranslationService) LocalizeFeed(ctx context.Context, items []store.FeedItem, locale string) error {
+	postIDs := make([]int64, 0, len(items))
+	for _, item := range items {
+		postIDs = append(postIDs, item.ID)
+	}
+
+	translations, err := s.store.Translations.GetForPosts(ctx, postIDs, locale)
+	if err != nil {
+		return err
+	}
+
+	for i := range items {
+		if t, ok := translations[items[i].ID]; ok {
+			items[i].Title = t.Title
+			items[i].Introduction = t.Introduction
+		}
+		items[i].Locale = locale
+	}
+
+	return nil
+}
+
+func (s *TranslationService) GetByPostID(ctx context.Context, postID int64) ([]store.Translation, error) {
+	return s.store.Translations.GetByPostID(ctx, postID)
+}
+
+// Put creates or replaces the translation of a post. The slug is derived
+// from the title when it is empty. created reports whether the translation
+// is new.
+func (s *TranslationService) Put(ctx context.Context, post *store.Post, translation *store.Translation) (bool, error) {
+	locale, err := s.ParseLocale(translation.Locale)
+	if err != nil {
+		return false, err
+	}
+
+	if locale == post.Locale {
+		return false, ErrOriginalLocale
+	}
+
+	slug := translation.Slug
+	if slug == "" {
+		slug = translation.Title
+	}
+
+	translation.PostID = post.ID
+	translation.Locale = locale
+	translation.Slug = slugify(slug)
+	if translation.Slug == "" {
+		return false, ErrInvalidSlug
+	}
+
+	return s.store.Translations.Upsert(ctx, translation)
+}
+
+func (s *TranslationService) Delete(ctx context.Context, postID int64, locale string) error {
+	locale, err := s.ParseLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Translations.Delete(ctx, postID, locale)
+}
+
+// GetPostIDBySlug returns the post translated to locale under slug
+func (s *TranslationService) GetPostIDBySlug(ctx context.Context, locale, slug string) (int64, error) {
+	locale, err := s.ParseLocale(locale)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.store.Translations.GetPostIDBySlug(ctx, locale, slugify(slug))
+}
+
+// slugify lowercases s and joins its words with dashes. Combining marks are
+// kept since scripts like Thai write vowels with them.
+func slugify(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+	})
+
+	return strings.Join(words, "-")
+}
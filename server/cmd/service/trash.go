@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type TrashResponse struct {
+	Items      []store.TrashedPost `json:"items"`
+	Total      int64               `json:"total"`
+	Page       int                 `json:"page"`
+	PageSize   int                 `json:"page_size"`
+	TotalPages int                 `json:"total_pages"`
+}
+
+// GetTrash lists the trashed posts of an author, or of everyone when userID
+// is zero, along with the time each one will be purged
+func (s *PostService) GetTrash(ctx context.Context, userID int64, page, limit int) (*TrashResponse, error) {
+	posts, total, err := s.store.Posts.GetTrash(ctx, userID, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range posts {
+		s.setPurgeAt(&posts[i])
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &TrashResponse{
+		Items:      posts,
+		Total:      total,
+		Page:       page,
+		PageSize:   limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (s *PostService) GetTrashed(ctx context.Context, postID int64) (*store.TrashedPost, error) {
+	post, err := s.store.Posts.GetTrashedByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.setPurgeAt(post)
+	return post, nil
+}
+
+func (s *PostService) Restore(ctx context.Context, postID int64) error {
+	if err := s.store.Posts.Restore(ctx, postID); err != nil {
+		return err
+	}
+
+	s.invalidateRelated(ctx)
+	return nil
+}
+
+func (s *PostService) Purge(ctx context.Context, postID int64) error {
+	if err := s.store.Posts.Purge(ctx, postID); err != nil {
+		return err
+	}
+
+	s.invalidateRelated(ctx)
+	return nil
+}
+
+// PurgeExpiredTrash permanently deletes the posts that stayed in the trash
+// longer than the retention period
+func (s *PostService) PurgeExpiredTrash(ctx context.Context) (int64, error) {
+	return s.store.Posts.PurgeDeletedBefore(ctx, time.Now().Add(-s.trashRetention))
+}
+
+func (s *PostService) setPurgeAt(post *store.TrashedPost) {
+	deletedAt, err := time.Parse(time.RFC3339, post.DeletedAt)
+	if err != nil {
+		s.logger.Warnw("failed to parse deleted_at", "post_id", post.ID, "error", err)
+		return
+	}
+
+	post.PurgeAt = deletedAt.Add(s.trashRetention).Format(time.RFC3339)
+}
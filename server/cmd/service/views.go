@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+	"github.com/ritchie-gr8/my-blog-app/internal/store/cache"
+	"go.uber.org/zap"
+)
+
+type AnalyticsTotals struct {
+	Views    int64 `json:"views"`
+	Likes    int64 `json:"likes"`
+	Comments int64 `json:"comments"`
+}
+
+type AnalyticsResponse struct {
+	From   string                `json:"from"`
+	To     string                `json:"to"`
+	Totals AnalyticsTotals       `json:"totals"`
+	Posts  []store.PostAnalytics `json:"posts"`
+}
+
+type ViewService struct {
+	store      store.Storage
+	cacheStore cache.Storage
+	logger     *zap.SugaredLogger
+	window     time.Duration
+	secret     []byte
+	// local dedupes and buffers views when redis is not available
+	local *viewBuffer
+}
+
+// Record counts a view of a post unless the viewer already saw it within the
+// dedup window. Views are buffered and written to the database by Flush.
+func (s *ViewService) Record(ctx context.Context, postID int64, viewer string) {
+	first, err := s.cacheStore.Views.MarkViewed(ctx, postID, viewer, s.window)
+	if err != nil {
+		if !errors.Is(err, cache.ErrRedisNotInit) {
+			s.logger.Warnw("failed to dedupe view in cache", "postID", postID, "error", err.Error())
+		}
+		first = s.local.markViewed(postID, viewer, s.window)
+	}
+
+	if !first {
+		return
+	}
+
+	key := store.PostViewKey{PostID: postID, Day: time.Now().UTC().Format(time.DateOnly)}
+	if err := s.cacheStore.Views.Increment(ctx, key); err != nil {
+		if !errors.Is(err, cache.ErrRedisNotInit) {
+			s.logger.Warnw("failed to buffer view in cache", "postID", postID, "error", err.Error())
+		}
+		s.local.add(map[store.PostViewKey]int64{key: 1})
+	}
+}
+
+// ViewerFingerprint identifies an anonymous viewer without keeping their IP
+// address or user agent around
+func (s *ViewService) ViewerFingerprint(ip, userAgent string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(ip + "\x00" + userAgent))
+	return "anon:" + hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+func (s *ViewService) UserViewer(userID int64) string {
+	return "user:" + strconv.FormatInt(userID, 10)
+}
+
+// Flush writes the buffered views to the database in one batch and returns
+// how many were written. On failure the views go back to the local buffer
+// for the next flush.
+func (s *ViewService) Flush(ctx context.Context) (int64, error) {
+	counts := s.local.drain()
+
+	cached, err := s.cacheStore.Views.Drain(ctx)
+	if err != nil && !errors.Is(err, cache.ErrRedisNotInit) {
+		s.logger.Warnw("failed to drain cached views", "error", err.Error())
+	}
+	for key, n := range cached {
+		counts[key] += n
+	}
+
+	if err := s.store.PostViews.AddDaily(ctx, counts); err != nil {
+		s.local.add(counts)
+		return 0, err
+	}
+
+	var total int64
+	for _, n := range counts {
+		total += n
+	}
+
+	return total, nil
+}
+
+func (s *ViewService) GetAnalytics(ctx context.Context, userID int64, from, to time.Time) (*AnalyticsResponse, error) {
+	posts, err := s.store.PostViews.GetAuthorAnalytics(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var totals AnalyticsTotals
+	for _, post := range posts {
+		totals.Views += post.Views
+		totals.Likes += post.Likes
+		totals.Comments += post.Comments
+	}
+
+	return &AnalyticsResponse{
+		From:   from.Format(time.DateOnly),
+		To:     to.Format(time.DateOnly),
+		Totals: totals,
+		Posts:  posts,
+	}, nil
+}
+
+// viewBuffer is the in-process fallback of the redis view buffer. It only
+// dedupes views seen by this instance.
+type viewBuffer struct {
+	mu     sync.Mutex
+	seen   map[string]time.Time
+	counts map[store.PostViewKey]int64
+}
+
+func newViewBuffer() *viewBuffer {
+	return &viewBuffer{
+		seen:   map[string]time.Time{},
+		counts: map[store.PostViewKey]int64{},
+	}
+}
+
+func (b *viewBuffer) markViewed(postID int64, viewer string, window time.Duration) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := strconv.FormatInt(postID, 10) + ":" + viewer
+	now := time.Now()
+	if expiresAt, ok := b.seen[key]; ok && now.Before(expiresAt) {
+		return false
+	}
+
+	b.seen[key] = now.Add(window)
+	return true
+}
+
+func (b *viewBuffer) add(counts map[store.PostViewKey]int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for key, n := range counts {
+		b.counts[key] += n
+	}
+}
+
+// drain returns the buffered views and forgets the expired dedup entries
+func (b *viewBuffer) drain() map[store.PostViewKey]int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	for key, expiresAt := range b.seen {
+		if !now.Before(expiresAt) {
+			delete(b.seen, key)
+		}
+	}
+
+	counts := b.counts
+	b.counts = map[store.PostViewKey]int64{}
+	return counts
+}
@@ -0,0 +1,45 @@
+package cursor
+
+import (
+	"errors"
+	"testing"
+)
+
+type testCursor struct {
+	ID   int64  `json:"i"`
+	Sort string `json:"s"`
+}
+
+func TestSigner(t *testing.T) {
+	signer := NewSigner("secret")
+
+	encoded, err := signer.Encode(testCursor{ID: 42, Sort: "desc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("should decode a cursor it encoded", func(t *testing.T) {
+		var c testCursor
+		if err := signer.Decode(encoded, &c); err != nil {
+			t.Fatal(err)
+		}
+
+		if c.ID != 42 || c.Sort != "desc" {
+			t.Errorf("unexpected cursor payload %+v", c)
+		}
+	})
+
+	t.Run("should reject a tampered cursor", func(t *testing.T) {
+		forged, err := NewSigner("other-secret").Encode(testCursor{ID: 1, Sort: "desc"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, cursor := range []string{forged, encoded + "x", "garbage", ""} {
+			var c testCursor
+			if err := signer.Decode(cursor, &c); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("expected ErrInvalidCursor for %q; got %v", cursor, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package markdown
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	MaxFiles    = 1000
+	MaxFileSize = 1 << 20 // 1mb
+	// MaxArchiveSize bounds the Markdown read from an archive, all files
+	// together, since it is held in memory
+	MaxArchiveSize = 50 << 20 // 50mb
+)
+
+var (
+	ErrTooManyFiles    = fmt.Errorf("archive has more than %d Markdown files", MaxFiles)
+	ErrFileTooLarge    = fmt.Errorf("file is larger than %d bytes", MaxFileSize)
+	ErrArchiveTooLarge = fmt.Errorf("archive has more than %d bytes of Markdown", MaxArchiveSize)
+)
+
+// File is a Markdown file of an archive. Err is set instead of Document when
+// the file could not be read, so one bad file does not fail a whole import.
+type File struct {
+	Path     string
+	Document *Document
+	Err      error
+}
+
+// Key identifies the post of the file across imports: its slug, or its path
+// without the extension
+func (f File) Key() string {
+	if f.Document != nil {
+		if slug := strings.TrimSpace(f.Document.Slug); slug != "" {
+			return slug
+		}
+	}
+
+	return strings.TrimSuffix(f.Path, path.Ext(f.Path))
+}
+
+// ReadArchive reads the Markdown files of a zip archive. Other files, such as
+// images, are ignored.
+func ReadArchive(r io.ReaderAt, size int64) ([]File, error) {
+	return readArchive(r, size, MaxArchiveSize)
+}
+
+// readArchive reads an archive until budget bytes of Markdown are
+// decompressed, counted on the bytes actually read since the sizes in the
+// headers are not trusted
+func readArchive(r io.ReaderAt, size int64, budget int64) ([]File, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []File
+	var total int64
+	for _, entry := range archive.File {
+		name := path.Clean(strings.TrimPrefix(entry.Name, "/"))
+		if entry.FileInfo().IsDir() || !isMarkdown(name) || isHidden(name) {
+			continue
+		}
+
+		if len(files) == MaxFiles {
+			return nil, ErrTooManyFiles
+		}
+
+		file := File{Path: name}
+		var read int64
+		file.Document, read, file.Err = readFile(entry)
+
+		total += read
+		if total > budget {
+			return nil, ErrArchiveTooLarge
+		}
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// readFile parses a Markdown file of an archive and returns the number of
+// bytes decompressed to read it
+func readFile(entry *zip.File) (*Document, int64, error) {
+	if entry.UncompressedSize64 > MaxFileSize {
+		return nil, 0, ErrFileTooLarge
+	}
+
+	rc, err := entry.Open()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rc.Close()
+
+	// the size in the header is not trusted
+	data, err := io.ReadAll(io.LimitReader(rc, MaxFileSize+1))
+	read := int64(len(data))
+	if err != nil {
+		return nil, read, err
+	}
+	if len(data) > MaxFileSize {
+		return nil, read, ErrFileTooLarge
+	}
+
+	doc, err := Parse(data)
+	return doc, read, err
+}
+
+// WriteArchive writes the documents of files to a zip archive
+func WriteArchive(w io.Writer, files []File) error {
+	archive := zip.NewWriter(w)
+	now := time.Now()
+
+	for _, file := range files {
+		if file.Document == nil {
+			return errors.New("cannot write a file without document")
+		}
+
+		data, err := Render(file.Document)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file.Path, err)
+		}
+
+		fw, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.Path,
+			Method:   zip.Deflate,
+			Modified: now,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := fw.Write(data); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+func isMarkdown(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".md", ".markdown":
+		return true
+	default:
+		return false
+	}
+}
+
+// isHidden skips dot files and the metadata folders added by archivers
+func isHidden(name string) bool {
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") || part == "__MACOSX" {
+			return true
+		}
+	}
+	return false
+}
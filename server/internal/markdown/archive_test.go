@@ -0,0 +1,106 @@
+package markdown
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func zipFiles(t *testing.T, files map[string]string) *bytes.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestReadArchive(t *testing.T) {
+	r := zipFiles(t, map[string]string{
+		"posts/hello.md":         "---\ntitle: Hello\n---\nbody",
+		"posts/slugged.markdown": "---\ntitle: Slugged\nslug: custom\n---\nbody",
+		"posts/broken.md":        "no front matter",
+		"posts/large.md":         "---\ntitle: Large\n---\n" + strings.Repeat("a", MaxFileSize),
+		"posts/image.png":        "not markdown",
+		"__MACOSX/posts/._x.md":  "metadata",
+		".hidden.md":             "---\ntitle: Hidden\n---\n",
+	})
+
+	files, err := ReadArchive(r, r.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]File{}
+	for _, file := range files {
+		got[file.Path] = file
+	}
+
+	if len(got) != 4 {
+		t.Fatalf("expected 4 Markdown files; got %d", len(got))
+	}
+
+	if file := got["posts/hello.md"]; file.Err != nil || file.Key() != "posts/hello" {
+		t.Errorf("unexpected file %+v with key %q", file, file.Key())
+	}
+
+	if file := got["posts/slugged.markdown"]; file.Key() != "custom" {
+		t.Errorf("expected the slug to be the key; got %q", file.Key())
+	}
+
+	if file := got["posts/broken.md"]; !errors.Is(file.Err, ErrNoFrontMatter) {
+		t.Errorf("expected ErrNoFrontMatter; got %v", file.Err)
+	}
+
+	if file := got["posts/large.md"]; !errors.Is(file.Err, ErrFileTooLarge) {
+		t.Errorf("expected ErrFileTooLarge; got %v", file.Err)
+	}
+}
+
+func TestReadArchiveBudget(t *testing.T) {
+	r := zipFiles(t, map[string]string{
+		"a.md": "---\ntitle: A\n---\n" + strings.Repeat("a", 600),
+		"b.md": "---\ntitle: B\n---\n" + strings.Repeat("b", 600),
+	})
+
+	if _, err := readArchive(r, r.Size(), 1000); !errors.Is(err, ErrArchiveTooLarge) {
+		t.Errorf("expected ErrArchiveTooLarge; got %v", err)
+	}
+
+	if _, err := readArchive(r, r.Size(), 2000); err != nil {
+		t.Errorf("expected the archive to fit the budget; got %v", err)
+	}
+}
+
+func TestWriteArchive(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteArchive(&buf, []File{
+		{Path: "alice/hello.md", Document: &Document{FrontMatter: FrontMatter{Title: "Hello", Slug: "hello"}, Body: "body"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := ReadArchive(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 1 || files[0].Err != nil || files[0].Key() != "hello" || files[0].Document.Body != "body" {
+		t.Errorf("unexpected files %+v", files)
+	}
+}
@@ -0,0 +1,150 @@
+// Package markdown converts posts from and to Markdown files with a YAML
+// front matter, the format used by most static site generators:
+//
+//	---
+//	title: Hello
+//	intro: A first post
+//	category: News
+//	status: Published
+//	date: 2024-05-01
+//	tags: [go, blog]
+//	---
+//
+//	The content of the post.
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrNoFrontMatter = errors.New("missing front matter")
+	ErrInvalidDate   = errors.New("invalid date")
+)
+
+const delimiter = "---"
+
+// dateLayouts are tried in order when parsing the date of the front matter,
+// dates without a zone are taken as UTC
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 -07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+type FrontMatter struct {
+	Title    string `yaml:"title"`
+	Intro    string `yaml:"intro,omitempty"`
+	Category string `yaml:"category"`
+	Status   string `yaml:"status,omitempty"`
+	Date     string `yaml:"date,omitempty"`
+	Tags     Tags   `yaml:"tags,omitempty"`
+	// Slug identifies the post across imports, the file path is used when
+	// it is missing
+	Slug string `yaml:"slug,omitempty"`
+	// Author is the username of the author, only used by whole blog imports
+	Author string `yaml:"author,omitempty"`
+}
+
+// Tags accepts both a YAML list and a comma separated string
+type Tags []string
+
+func (t *Tags) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = nil
+		for _, tag := range strings.Split(node.Value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				*t = append(*t, tag)
+			}
+		}
+		return nil
+	}
+
+	var tags []string
+	if err := node.Decode(&tags); err != nil {
+		return err
+	}
+	*t = tags
+	return nil
+}
+
+type Document struct {
+	FrontMatter
+	Body string
+}
+
+// ParseDate parses the date of the front matter, a zero time is returned
+// when it is empty
+func (d *Document) ParseDate() (time.Time, error) {
+	date := strings.TrimSpace(d.Date)
+	if date == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, date); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w %q", ErrInvalidDate, date)
+}
+
+// Parse reads a Markdown document starting with a YAML front matter
+func Parse(data []byte) (*Document, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	text = strings.TrimPrefix(text, "\ufeff")
+
+	if !strings.HasPrefix(text, delimiter+"\n") {
+		return nil, ErrNoFrontMatter
+	}
+	text = text[len(delimiter)+1:]
+
+	var header, body string
+	if strings.HasPrefix(text, delimiter+"\n") || text == delimiter {
+		body = strings.TrimPrefix(text, delimiter)
+	} else {
+		end := strings.Index(text, "\n"+delimiter+"\n")
+		if end < 0 {
+			if !strings.HasSuffix(text, "\n"+delimiter) {
+				return nil, ErrNoFrontMatter
+			}
+			end = len(text) - len(delimiter) - 1
+		}
+		header = text[:end]
+		body = text[min(end+len(delimiter)+2, len(text)):]
+	}
+
+	doc := &Document{Body: strings.Trim(body, "\n")}
+	if err := yaml.Unmarshal([]byte(header), &doc.FrontMatter); err != nil {
+		return nil, fmt.Errorf("invalid front matter: %w", err)
+	}
+
+	return doc, nil
+}
+
+// Render writes a document in the format read by Parse
+func Render(doc *Document) ([]byte, error) {
+	header, err := yaml.Marshal(doc.FrontMatter)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(delimiter + "\n")
+	buf.Write(header)
+	buf.WriteString(delimiter + "\n\n")
+	buf.WriteString(doc.Body)
+	buf.WriteString("\n")
+
+	return buf.Bytes(), nil
+}
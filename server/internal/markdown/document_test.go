@@ -0,0 +1,109 @@
+package markdown
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("should read the front matter and body", func(t *testing.T) {
+		data := "---\r\ntitle: Hello\r\nintro: First post\r\ncategory: News\r\ntags: [go, blog]\r\n---\r\n\r\n# Hello\r\n\r\nWorld\r\n"
+
+		doc, err := Parse([]byte(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if doc.Title != "Hello" || doc.Intro != "First post" || doc.Category != "News" {
+			t.Errorf("unexpected front matter %+v", doc.FrontMatter)
+		}
+		if !slices.Equal(doc.Tags, []string{"go", "blog"}) {
+			t.Errorf("unexpected tags %v", doc.Tags)
+		}
+		if doc.Body != "# Hello\n\nWorld" {
+			t.Errorf("unexpected body %q", doc.Body)
+		}
+	})
+
+	t.Run("should accept comma separated tags", func(t *testing.T) {
+		doc, err := Parse([]byte("---\ntags: go, blog ,\n---\nbody"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(doc.Tags, []string{"go", "blog"}) {
+			t.Errorf("unexpected tags %v", doc.Tags)
+		}
+	})
+
+	t.Run("should accept a document without body", func(t *testing.T) {
+		doc, err := Parse([]byte("---\ntitle: Empty\n---"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if doc.Title != "Empty" || doc.Body != "" {
+			t.Errorf("unexpected document %+v", doc)
+		}
+	})
+
+	t.Run("should reject a document without front matter", func(t *testing.T) {
+		for _, data := range []string{"# Hello", "---\ntitle: Hello\n", ""} {
+			if _, err := Parse([]byte(data)); !errors.Is(err, ErrNoFrontMatter) {
+				t.Errorf("expected ErrNoFrontMatter for %q; got %v", data, err)
+			}
+		}
+	})
+}
+
+func TestParseDate(t *testing.T) {
+	want := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, date := range []string{"2024-05-01", "2024-05-01 00:00:00", "2024-05-01T02:00:00+02:00", "2024-05-01 00:00:00 +0000"} {
+		doc := &Document{FrontMatter: FrontMatter{Date: date}}
+
+		got, err := doc.ParseDate()
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", date, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("expected %v for %q; got %v", want, date, got)
+		}
+	}
+
+	doc := &Document{FrontMatter: FrontMatter{Date: "yesterday"}}
+	if _, err := doc.ParseDate(); !errors.Is(err, ErrInvalidDate) {
+		t.Errorf("expected ErrInvalidDate; got %v", err)
+	}
+}
+
+func TestRender(t *testing.T) {
+	doc := &Document{
+		FrontMatter: FrontMatter{
+			Title:    "Hello: again",
+			Category: "News",
+			Status:   "Published",
+			Date:     "2024-05-01T00:00:00Z",
+			Tags:     Tags{"go"},
+			Slug:     "hello",
+		},
+		Body: "---\n\nContent",
+	}
+
+	data, err := Render(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if parsed.Title != doc.Title || parsed.Date != doc.Date || parsed.Slug != doc.Slug || parsed.Body != doc.Body {
+		t.Errorf("document changed after a round trip: %+v", parsed)
+	}
+}
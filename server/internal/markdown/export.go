@@ -0,0 +1,61 @@
+package markdown
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+// Export returns a file for each post of a user, or of the whole blog when
+// userID is 0. Whole blog exports put the files of each author in a folder
+// named after them.
+func Export(ctx context.Context, storage store.Storage, userID int64) ([]File, error) {
+	posts, err := storage.Posts.GetForExport(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]File, 0, len(posts))
+	for _, post := range posts {
+		key := post.ImportKey
+		if key == "" {
+			key = fmt.Sprintf("%d-%s", post.ID, slugify(post.Title))
+		}
+
+		filePath := key + ".md"
+		if userID == 0 {
+			filePath = post.AuthorUsername + "/" + filePath
+		}
+
+		files = append(files, File{
+			Path: filePath,
+			Document: &Document{
+				FrontMatter: FrontMatter{
+					Title:    post.Title,
+					Intro:    post.Introduction,
+					Category: post.Category,
+					Status:   post.Status,
+					Date:     post.CreatedAt,
+					Tags:     post.Tags,
+					Slug:     key,
+					Author:   post.AuthorUsername,
+				},
+				Body: post.Content,
+			},
+		})
+	}
+
+	return files, nil
+}
+
+// slugify keeps the letters and digits of s, lowercased and joined by dashes
+func slugify(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(words, "-")
+}
@@ -0,0 +1,439 @@
+package markdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+const (
+	ResultCreated   = "created"
+	ResultUpdated   = "updated"
+	ResultUnchanged = "unchanged"
+	ResultFailed    = "failed"
+)
+
+// limits of the post payloads of the API, the content is only bounded by
+// MaxFileSize since imported posts are usually longer
+const (
+	maxTitleLength = 100
+	maxIntroLength = 120
+	maxKeyLength   = 200
+	maxTags        = 10
+	maxTagLength   = 30
+)
+
+// knownStatuses maps the lowercased statuses to their stored form
+var knownStatuses = map[string]string{
+	strings.ToLower(store.PostStatusDraft):            store.PostStatusDraft,
+	strings.ToLower(store.PostStatusSubmitted):        store.PostStatusSubmitted,
+	strings.ToLower(store.PostStatusInReview):         store.PostStatusInReview,
+	strings.ToLower(store.PostStatusApproved):         store.PostStatusApproved,
+	strings.ToLower(store.PostStatusChangesRequested): store.PostStatusChangesRequested,
+	strings.ToLower(store.PostStatusPublished):        store.PostStatusPublished,
+}
+
+type Options struct {
+	// UserID owns the imported posts and is recorded as the author of their
+	// status changes
+	UserID int64
+	// Moderator can publish to categories that require review and restore
+	// posts in any status, other users follow the review workflow
+	Moderator bool
+	// KeepAuthors assigns the posts to the user named by their front matter
+	// author, to restore a whole blog export
+	KeepAuthors bool
+	// SearchLanguage is the text search configuration the posts are indexed
+	// with, the store default when empty
+	SearchLanguage string
+}
+
+type FileResult struct {
+	Path   string `json:"path"`
+	Slug   string `json:"slug"`
+	PostID int64  `json:"post_id,omitempty"`
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Created   int          `json:"created"`
+	Updated   int          `json:"updated"`
+	Unchanged int          `json:"unchanged"`
+	Failed    int          `json:"failed"`
+	Files     []FileResult `json:"files"`
+}
+
+// fileError rejects a single file without stopping the import
+type fileError struct {
+	msg string
+}
+
+func (e *fileError) Error() string {
+	return e.msg
+}
+
+func rejectf(format string, args ...any) error {
+	return &fileError{msg: fmt.Sprintf(format, args...)}
+}
+
+type importer struct {
+	store      store.Storage
+	opts       Options
+	categories map[string]*store.Category
+	authors    map[string]int64
+	keys       map[string]bool
+}
+
+// Import creates or updates a post for each file. Posts are matched on the
+// file key and their author, so importing the same files again leaves the
+// posts unchanged. Invalid files are reported and skipped, an error is only
+// returned when the import cannot go on.
+func Import(ctx context.Context, storage store.Storage, files []File, opts Options) (*Report, error) {
+	categories, err := storage.Categories.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	im := &importer{
+		store:      storage,
+		opts:       opts,
+		categories: make(map[string]*store.Category, len(categories)),
+		authors:    map[string]int64{},
+		keys:       map[string]bool{},
+	}
+	for _, category := range categories {
+		im.categories[strings.ToLower(category.Name)] = category
+	}
+
+	report := &Report{Files: make([]FileResult, 0, len(files))}
+	for _, file := range files {
+		result := FileResult{Path: file.Path, Slug: file.Key()}
+
+		postID, outcome, err := im.importFile(ctx, file)
+		var rejected *fileError
+		switch {
+		case err == nil:
+			result.PostID = postID
+			result.Result = outcome
+		case errors.As(err, &rejected):
+			result.Result = ResultFailed
+			result.Error = rejected.Error()
+		default:
+			return nil, fmt.Errorf("%s: %w", file.Path, err)
+		}
+
+		switch result.Result {
+		case ResultCreated:
+			report.Created++
+		case ResultUpdated:
+			report.Updated++
+		case ResultUnchanged:
+			report.Unchanged++
+		default:
+			report.Failed++
+		}
+		report.Files = append(report.Files, result)
+	}
+
+	return report, nil
+}
+
+func (im *importer) importFile(ctx context.Context, file File) (int64, string, error) {
+	if file.Err != nil {
+		return 0, "", rejectf("%v", file.Err)
+	}
+
+	post, err := im.toPost(ctx, file)
+	if err != nil {
+		return 0, "", err
+	}
+
+	id, err := im.store.Posts.GetIDByImportKey(ctx, post.UserID, post.ImportKey)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return im.create(ctx, post)
+	case err != nil:
+		return 0, "", err
+	}
+
+	existing, err := im.store.Posts.GetByID(ctx, id, 0)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return im.update(ctx, existing, post)
+}
+
+func (im *importer) toPost(ctx context.Context, file File) (*store.Post, error) {
+	doc := file.Document
+
+	key := file.Key()
+	if key == "" || utf8.RuneCountInString(key) > maxKeyLength {
+		return nil, rejectf("slug must be between 1 and %d characters", maxKeyLength)
+	}
+
+	authorID, err := im.authorID(ctx, doc.Author)
+	if err != nil {
+		return nil, err
+	}
+
+	// keys are unique per author, two files of the archive would otherwise
+	// keep overwriting the same post
+	keyID := fmt.Sprintf("%d:%s", authorID, key)
+	if im.keys[keyID] {
+		return nil, rejectf("duplicate slug %q", key)
+	}
+	im.keys[keyID] = true
+
+	post := &store.Post{
+		Title:          strings.TrimSpace(doc.Title),
+		Introduction:   strings.TrimSpace(doc.Intro),
+		Content:        doc.Body,
+		UserID:         authorID,
+		ImportKey:      key,
+		SearchLanguage: im.opts.SearchLanguage,
+	}
+
+	if post.Title == "" {
+		return nil, rejectf("title is required")
+	}
+	if utf8.RuneCountInString(post.Title) > maxTitleLength {
+		return nil, rejectf("title must be at most %d characters", maxTitleLength)
+	}
+
+	if strings.TrimSpace(post.Content) == "" {
+		return nil, rejectf("content is required")
+	}
+
+	if post.Introduction == "" {
+		post.Introduction = deriveIntro(post.Content)
+	}
+	if utf8.RuneCountInString(post.Introduction) > maxIntroLength {
+		return nil, rejectf("intro must be at most %d characters", maxIntroLength)
+	}
+
+	category, ok := im.categories[strings.ToLower(strings.TrimSpace(doc.Category))]
+	if !ok {
+		return nil, rejectf("unknown category %q", doc.Category)
+	}
+	post.CategoryID = category.ID
+
+	if status := strings.TrimSpace(doc.Status); status != "" {
+		post.Status, ok = knownStatuses[strings.ToLower(status)]
+		if !ok {
+			return nil, rejectf("unknown status %q", doc.Status)
+		}
+	}
+
+	post.Tags, err = normalizeTags(doc.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := doc.ParseDate()
+	if err != nil {
+		return nil, rejectf("%v", err)
+	}
+	if !date.IsZero() {
+		post.CreatedAt = date.Format(time.RFC3339)
+	}
+
+	return post, nil
+}
+
+func (im *importer) create(ctx context.Context, post *store.Post) (int64, string, error) {
+	if post.Status == "" {
+		post.Status = store.PostStatusPublished
+	}
+
+	if err := im.checkTransition(post, store.PostStatusDraft, true); err != nil {
+		return 0, "", err
+	}
+
+	if err := im.store.Posts.Create(ctx, post); err != nil {
+		if errors.Is(err, store.ErrUniqueViolation) {
+			return 0, "", rejectf("a post with slug %q is in the trash", post.ImportKey)
+		}
+		return 0, "", err
+	}
+
+	if err := im.recordStatusChange(ctx, post.ID, store.PostStatusDraft, post.Status); err != nil {
+		return 0, "", err
+	}
+
+	return post.ID, ResultCreated, nil
+}
+
+func (im *importer) update(ctx context.Context, existing, post *store.Post) (int64, string, error) {
+	if post.Status == "" {
+		post.Status = existing.Status
+	}
+
+	contentChanged := existing.Title != post.Title || existing.Introduction != post.Introduction ||
+		existing.Content != post.Content || existing.CategoryID != post.CategoryID ||
+		!slices.Equal(existing.Tags, post.Tags)
+
+	if !contentChanged && existing.Status == post.Status {
+		return existing.ID, ResultUnchanged, nil
+	}
+
+	// only moderators set the review steps, a file asking for Approved is
+	// submitted to review instead
+	if !im.opts.Moderator && strings.EqualFold(post.Status, store.PostStatusApproved) {
+		post.Status = store.PostStatusSubmitted
+	}
+
+	previousStatus := existing.Status
+	if err := im.checkTransition(post, previousStatus, contentChanged); err != nil {
+		return 0, "", err
+	}
+
+	existing.Title = post.Title
+	existing.Introduction = post.Introduction
+	existing.Content = post.Content
+	existing.CategoryID = post.CategoryID
+	existing.Status = post.Status
+	existing.Tags = post.Tags
+	existing.SearchLanguage = post.SearchLanguage
+
+	if err := im.store.Posts.Update(ctx, existing); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return 0, "", rejectf("post was modified during the import")
+		}
+		return 0, "", err
+	}
+
+	if err := im.recordStatusChange(ctx, existing.ID, previousStatus, existing.Status); err != nil {
+		return 0, "", err
+	}
+
+	return existing.ID, ResultUpdated, nil
+}
+
+// checkTransition settles the status the import leaves post in and checks
+// the import may move it there, as with edits through the API: approved
+// posts, and published posts needing review, go back to review when their
+// author changes them, even when the file asks to publish them
+func (im *importer) checkTransition(post *store.Post, from string, contentChanged bool) error {
+	if im.opts.Moderator {
+		return nil
+	}
+
+	var requiresReview bool
+	for _, category := range im.categories {
+		if category.ID == post.CategoryID {
+			requiresReview = category.RequiresReview
+			break
+		}
+	}
+
+	post.Status = store.StatusAfterEdit(from, post.Status, contentChanged, requiresReview, false)
+	err := store.CheckStatusTransition(from, post.Status, contentChanged, requiresReview, false)
+	switch {
+	case errors.Is(err, store.ErrReviewRequired):
+		return rejectf("category requires review, import the post as Draft or Submitted")
+	case errors.Is(err, store.ErrInvalidTransition):
+		return rejectf("cannot change status from %s to %s", from, post.Status)
+	default:
+		return err
+	}
+}
+
+func (im *importer) recordStatusChange(ctx context.Context, postID int64, from, to string) error {
+	action := store.ReviewActionForStatusChange(from, to)
+	if action == "" {
+		return nil
+	}
+
+	userID := im.opts.UserID
+	return im.store.Reviews.Create(ctx, &store.Review{
+		PostID:     postID,
+		UserID:     &userID,
+		Action:     action,
+		FromStatus: from,
+		ToStatus:   to,
+	})
+}
+
+func (im *importer) authorID(ctx context.Context, username string) (int64, error) {
+	username = strings.TrimSpace(username)
+	if !im.opts.KeepAuthors || username == "" {
+		return im.opts.UserID, nil
+	}
+
+	if id, ok := im.authors[username]; ok {
+		return id, nil
+	}
+
+	user, err := im.store.Users.GetByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return 0, rejectf("unknown author %q", username)
+		}
+		return 0, err
+	}
+
+	im.authors[username] = user.ID
+	return user.ID, nil
+}
+
+// normalizeTags trims the tags and drops the duplicates, keeping their order
+func normalizeTags(tags Tags) ([]string, error) {
+	normalized := []string{}
+	seen := map[string]bool{}
+
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, rejectf("tag %q is longer than %d characters", tag, maxTagLength)
+		}
+
+		seen[strings.ToLower(tag)] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > maxTags {
+		return nil, rejectf("a post can have at most %d tags", maxTags)
+	}
+
+	return normalized, nil
+}
+
+// deriveIntro uses the first paragraph of the content when the front matter
+// has no intro, cut to fit. Headings are skipped.
+func deriveIntro(content string) string {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			line = ""
+		}
+
+		line = strings.TrimSpace(strings.TrimLeft(line, ">*-"))
+		if line == "" {
+			if len(lines) > 0 {
+				break
+			}
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	intro := strings.Join(lines, " ")
+	if utf8.RuneCountInString(intro) <= maxIntroLength {
+		return intro
+	}
+
+	runes := []rune(intro)
+	return strings.TrimSpace(string(runes[:maxIntroLength-3])) + "..."
+}
@@ -0,0 +1,37 @@
+package markdown
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := normalizeTags(Tags{" Go ", "blog", "go", ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(tags, []string{"Go", "blog"}) {
+		t.Errorf("unexpected tags %v", tags)
+	}
+
+	if _, err := normalizeTags(Tags{strings.Repeat("a", maxTagLength+1)}); err == nil {
+		t.Error("expected an error for a tag that is too long")
+	}
+}
+
+func TestDeriveIntro(t *testing.T) {
+	if intro := deriveIntro("# Title\n\nFirst line\nsecond line\n\nNext paragraph"); intro != "First line second line" {
+		t.Errorf("unexpected intro %q", intro)
+	}
+
+	if intro := deriveIntro("> Quoted\n## Heading\nNext"); intro != "Quoted" {
+		t.Errorf("unexpected intro %q", intro)
+	}
+
+	intro := deriveIntro(strings.Repeat("word ", 100))
+	if len([]rune(intro)) > maxIntroLength || !strings.HasSuffix(intro, "...") {
+		t.Errorf("expected the intro to be cut; got %q", intro)
+	}
+}
@@ -0,0 +1,202 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+const jpegQuality = 85
+
+// Limits bound what an upload may contain. Dimensions are checked from the
+// image header, before anything is decoded.
+type Limits struct {
+	MaxBytes     int64
+	MaxDimension int
+	MaxPixels    int
+}
+
+type VariantSpec struct {
+	Name string
+	// MaxSize bounds the longest side of the variant, zero keeps the image size
+	MaxSize int
+}
+
+// Variants are generated from largest to smallest, each one is scaled down
+// from the previous one
+var Variants = []VariantSpec{
+	{Name: "original"},
+	{Name: "large", MaxSize: 1600},
+	{Name: "medium", MaxSize: 800},
+	{Name: "thumbnail", MaxSize: 320},
+}
+
+type Variant struct {
+	Name        string
+	ContentType string
+	Width       int
+	Height      int
+	Data        []byte
+}
+
+type Image struct {
+	// SourceType is the sniffed type of the upload, variants are JPEG for
+	// opaque images and PNG otherwise
+	SourceType string
+	Width      int
+	Height     int
+	Variants   []Variant
+}
+
+type decodeFunc func(*bytes.Reader) (image.Image, error)
+type decodeConfigFunc func(*bytes.Reader) (image.Config, error)
+
+var decoders = map[string]struct {
+	decode       decodeFunc
+	decodeConfig decodeConfigFunc
+}{
+	"image/jpeg": {
+		func(r *bytes.Reader) (image.Image, error) { return jpeg.Decode(r) },
+		func(r *bytes.Reader) (image.Config, error) { return jpeg.DecodeConfig(r) },
+	},
+	"image/png": {
+		func(r *bytes.Reader) (image.Image, error) { return png.Decode(r) },
+		func(r *bytes.Reader) (image.Config, error) { return png.DecodeConfig(r) },
+	},
+	"image/gif": {
+		func(r *bytes.Reader) (image.Image, error) { return gif.Decode(r) },
+		func(r *bytes.Reader) (image.Config, error) { return gif.DecodeConfig(r) },
+	},
+	"image/webp": {
+		func(r *bytes.Reader) (image.Image, error) { return webp.Decode(r) },
+		func(r *bytes.Reader) (image.Config, error) { return webp.DecodeConfig(r) },
+	},
+}
+
+// ProcessImage validates an uploaded image and renders its variants. The
+// type is sniffed from the content, never taken from the client. Variants
+// are re-encoded from pixels only, which drops EXIF and every other
+// metadata block; the EXIF orientation is applied first so photos keep
+// their intended rotation. Animated GIFs keep their first frame.
+func ProcessImage(data []byte, limits Limits) (*Image, error) {
+	if limits.MaxBytes > 0 && int64(len(data)) > limits.MaxBytes {
+		return nil, ErrTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	dec, ok := decoders[contentType]
+	if !ok {
+		return nil, ErrUnsupportedType
+	}
+
+	cfg, err := dec.decodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedType
+	}
+
+	if cfg.Width < 1 || cfg.Height < 1 ||
+		(limits.MaxDimension > 0 && (cfg.Width > limits.MaxDimension || cfg.Height > limits.MaxDimension)) ||
+		(limits.MaxPixels > 0 && cfg.Width*cfg.Height > limits.MaxPixels) {
+		return nil, ErrDimensions
+	}
+
+	src, err := dec.decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedType
+	}
+
+	if contentType == "image/jpeg" {
+		src = applyOrientation(src, jpegOrientation(data))
+	}
+
+	img := &Image{
+		SourceType: contentType,
+		Width:      src.Bounds().Dx(),
+		Height:     src.Bounds().Dy(),
+	}
+
+	current := src
+	var previous *Variant
+	for _, spec := range Variants {
+		scaled := scaleToFit(current, spec.MaxSize)
+
+		// nothing to scale, store the previous rendition under this name too
+		if previous != nil && scaled == current {
+			v := *previous
+			v.Name = spec.Name
+			img.Variants = append(img.Variants, v)
+			continue
+		}
+
+		v, err := encode(spec.Name, scaled)
+		if err != nil {
+			return nil, err
+		}
+
+		img.Variants = append(img.Variants, v)
+		previous = &img.Variants[len(img.Variants)-1]
+		current = scaled
+	}
+
+	return img, nil
+}
+
+// scaleToFit returns img itself when it already fits within maxSize
+func scaleToFit(img image.Image, maxSize int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if maxSize <= 0 || (w <= maxSize && h <= maxSize) {
+		return img
+	}
+
+	if w >= h {
+		h = max(1, h*maxSize/w)
+		w = maxSize
+	} else {
+		w = max(1, w*maxSize/h)
+		h = maxSize
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+func encode(name string, img image.Image) (Variant, error) {
+	var buf bytes.Buffer
+	v := Variant{
+		Name:   name,
+		Width:  img.Bounds().Dx(),
+		Height: img.Bounds().Dy(),
+	}
+
+	if isOpaque(img) {
+		v.ContentType = "image/jpeg"
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return v, err
+		}
+	} else {
+		v.ContentType = "image/png"
+		if err := png.Encode(&buf, img); err != nil {
+			return v, err
+		}
+	}
+
+	v.Data = buf.Bytes()
+	return v, nil
+}
+
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+
+	return false
+}
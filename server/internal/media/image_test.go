@@ -0,0 +1,125 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encodeJPEG(t *testing.T, w, h int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 0, 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// withOrientation inserts a minimal EXIF block right after the SOI marker
+func withOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte{'M', 'M', 0, 42, 0, 0, 0, 8, 0, 1}
+	entry := make([]byte, 12)
+	binary.BigEndian.PutUint16(entry[0:], exifOrientationTag)
+	binary.BigEndian.PutUint16(entry[2:], 3) // SHORT
+	binary.BigEndian.PutUint32(entry[4:], 1)
+	binary.BigEndian.PutUint16(entry[8:], orientation)
+	tiff = append(tiff, entry...)
+	tiff = append(tiff, 0, 0, 0, 0)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+func TestProcessImage(t *testing.T) {
+	limits := Limits{MaxBytes: 1 << 20, MaxDimension: 4000, MaxPixels: 4000 * 4000}
+
+	t.Run("applies orientation and strips exif", func(t *testing.T) {
+		data := withOrientation(encodeJPEG(t, 400, 200), 6)
+		if got := jpegOrientation(data); got != 6 {
+			t.Fatalf("expected orientation 6, got %d", got)
+		}
+
+		img, err := ProcessImage(data, limits)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if img.Width != 200 || img.Height != 400 {
+			t.Errorf("expected rotated 200x400 image, got %dx%d", img.Width, img.Height)
+		}
+
+		if len(img.Variants) != len(Variants) {
+			t.Fatalf("expected %d variants, got %d", len(Variants), len(img.Variants))
+		}
+
+		for _, v := range img.Variants {
+			if v.ContentType != "image/jpeg" {
+				t.Errorf("%s: expected image/jpeg, got %s", v.Name, v.ContentType)
+			}
+			if bytes.Contains(v.Data, []byte("Exif\x00\x00")) {
+				t.Errorf("%s: exif block was not stripped", v.Name)
+			}
+		}
+
+		thumb := img.Variants[len(img.Variants)-1]
+		if thumb.Width != 160 || thumb.Height != 320 {
+			t.Errorf("expected 160x320 thumbnail, got %dx%d", thumb.Width, thumb.Height)
+		}
+	})
+
+	t.Run("keeps transparency as png", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 10, 10))); err != nil {
+			t.Fatal(err)
+		}
+
+		img, err := ProcessImage(buf.Bytes(), limits)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if img.Variants[0].ContentType != "image/png" {
+			t.Errorf("expected image/png, got %s", img.Variants[0].ContentType)
+		}
+	})
+
+	t.Run("rejects other content", func(t *testing.T) {
+		_, err := ProcessImage([]byte("<html><body>not an image</body></html>"), limits)
+		if !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("expected ErrUnsupportedType, got %v", err)
+		}
+	})
+
+	t.Run("rejects oversized dimensions", func(t *testing.T) {
+		_, err := ProcessImage(encodeJPEG(t, 300, 10), Limits{MaxDimension: 200})
+		if !errors.Is(err, ErrDimensions) {
+			t.Errorf("expected ErrDimensions, got %v", err)
+		}
+	})
+
+	t.Run("rejects large files", func(t *testing.T) {
+		_, err := ProcessImage(encodeJPEG(t, 100, 100), Limits{MaxBytes: 10})
+		if !errors.Is(err, ErrTooLarge) {
+			t.Errorf("expected ErrTooLarge, got %v", err)
+		}
+	})
+}
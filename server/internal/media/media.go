@@ -0,0 +1,34 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	ErrUnsupportedType = errors.New("unsupported media type")
+	ErrTooLarge        = errors.New("media file is too large")
+	ErrDimensions      = errors.New("image dimensions are out of bounds")
+	ErrInvalidKey      = errors.New("invalid media key")
+)
+
+// MediaStore keeps the blobs of uploaded media. Keys are slash separated
+// relative paths generated by the server, never by clients.
+type MediaStore interface {
+	Put(ctx context.Context, key, contentType string, body io.ReadSeeker, size int64) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+// Ext returns the file extension used to store blobs of contentType
+func Ext(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	default:
+		return ""
+	}
+}
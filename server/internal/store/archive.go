@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type ArchiveMonth struct {
+	Year  int   `json:"year"`
+	Month int   `json:"month"`
+	Count int64 `json:"count"`
+	// From and To are the feed params listing the posts of the month
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ArchiveQuery filters the archive like the matching feed params
+type ArchiveQuery struct {
+	Author   string `json:"author" validate:"omitempty,max=255"`
+	Category string `json:"category" validate:"omitempty,max=255"`
+}
+
+// GetArchive counts the published posts of each month, in UTC, latest first
+func (s *PostStore) GetArchive(ctx context.Context, aq ArchiveQuery) ([]ArchiveMonth, error) {
+	whereConditions := []string{"p.deleted_at IS NULL", "p.status ILIKE 'published'"}
+	var queryParams []any
+
+	if aq.Author != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("u.username = $%d", len(queryParams)+1))
+		queryParams = append(queryParams, aq.Author)
+	}
+
+	if aq.Category != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("c.name = $%d", len(queryParams)+1))
+		queryParams = append(queryParams, aq.Category)
+	}
+
+	query := `
+		SELECT EXTRACT(YEAR FROM month)::int, EXTRACT(MONTH FROM month)::int, COUNT(*)
+		FROM (
+			SELECT date_trunc('month', p.created_at AT TIME ZONE 'UTC') AS month
+			FROM posts p
+			LEFT JOIN users u ON u.id = p.user_id
+			LEFT JOIN categories c ON c.id = p.category_id
+			WHERE ` + strings.Join(whereConditions, " AND ") + `
+		) months
+		GROUP BY month
+		ORDER BY month DESC
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	months := []ArchiveMonth{}
+	for rows.Next() {
+		var month ArchiveMonth
+		if err := rows.Scan(&month.Year, &month.Month, &month.Count); err != nil {
+			return nil, err
+		}
+
+		months = append(months, month)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return months, nil
+}
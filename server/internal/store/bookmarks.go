@@ -0,0 +1,141 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+)
+
+type BookmarkedPost struct {
+	FeedItem
+	BookmarkID   int64  `json:"bookmark_id"`
+	BookmarkedAt string `json:"bookmarked_at"`
+}
+
+// BookmarkCursor is the decoded keyset position of a bookmarks page
+type BookmarkCursor struct {
+	CreatedAt string `json:"t"`
+	ID        int64  `json:"i"`
+}
+
+type BookmarkStore struct {
+	db *sql.DB
+}
+
+func (s *BookmarkStore) Bookmark(ctx context.Context, postID, userID int64) error {
+	query := `
+		INSERT INTO bookmarks (post_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (post_id, user_id) DO NOTHING
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := s.db.ExecContext(ctx, query, postID, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *BookmarkStore) Unbookmark(ctx context.Context, postID, userID int64) error {
+	query := `
+		DELETE FROM bookmarks
+		WHERE post_id = $1 AND user_id = $2
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, postID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *BookmarkStore) HasUserBookmarked(ctx context.Context, postID, userID int64) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM bookmarks
+			WHERE post_id = $1 AND user_id = $2
+		)
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var exists bool
+	err := s.db.QueryRowContext(ctx, query, postID, userID).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// GetByUserID returns the bookmarks of a user after the given position, most
+// recent first. Bookmarks of trashed posts, and of posts of other authors that
+// went back to draft, are skipped.
+func (s *BookmarkStore) GetByUserID(ctx context.Context, userID int64, cursor *BookmarkCursor, limit int) ([]BookmarkedPost, bool, error) {
+	query := `
+		SELECT p.id, p.title, p.introduction, p.category_id, c.name AS category, p.updated_at, p.thumbnail_image,
+			p.user_id, u.name, u.profile_picture, p.status, ps.likes_count, ps.comments_count, ps.trending_score,
+			b.id, b.created_at
+		FROM bookmarks b
+		JOIN posts p ON p.id = b.post_id
+		JOIN post_stats ps ON ps.post_id = p.id
+		LEFT JOIN users u ON u.id = p.user_id
+		LEFT JOIN categories c ON c.id = p.category_id
+		WHERE b.user_id = $1 AND p.deleted_at IS NULL
+			AND (p.status ILIKE 'published' OR p.user_id = $1)
+			AND ($2::timestamptz IS NULL OR (b.created_at, b.id) < ($2::timestamptz, $3))
+		ORDER BY b.created_at DESC, b.id DESC
+		LIMIT $4
+	`
+	var after sql.NullString
+	var afterID int64
+	if cursor != nil {
+		after = sql.NullString{String: cursor.CreatedAt, Valid: true}
+		afterID = cursor.ID
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, userID, after, afterID, limit+1)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	posts := []BookmarkedPost{}
+	for rows.Next() {
+		var post BookmarkedPost
+		fields := append(feedItemFields(&post.FeedItem), &post.BookmarkID, &post.BookmarkedAt)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, false, err
+		}
+
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(posts) > limit
+	if hasMore {
+		posts = posts[:limit]
+	}
+
+	return posts, hasMore, nil
+}
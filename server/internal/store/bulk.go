@@ -0,0 +1,608 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	BulkTargetPosts    = "posts"
+	BulkTargetComments = "comments"
+
+	BulkActionDelete         = "delete"
+	BulkActionSetStatus      = "set_status"
+	BulkActionSetCategory    = "set_category"
+	BulkActionReassignAuthor = "reassign_author"
+
+	BulkStatusPending   = "pending"
+	BulkStatusRunning   = "running"
+	BulkStatusCompleted = "completed"
+	BulkStatusFailed    = "failed"
+)
+
+var ErrInvalidBulkAction = errors.New("invalid bulk action for this target")
+
+// BulkFilter selects the rows of a bulk operation. Every criterion that is
+// set has to match. CategoryID and Status only apply to posts, PostID only
+// to comments.
+type BulkFilter struct {
+	IDs        []int64    `json:"ids,omitempty"`
+	AuthorID   int64      `json:"author_id,omitempty"`
+	CategoryID int64      `json:"category_id,omitempty"`
+	Status     string     `json:"status,omitempty"`
+	PostID     int64      `json:"post_id,omitempty"`
+	From       *time.Time `json:"from,omitempty"`
+	To         *time.Time `json:"to,omitempty"`
+	// MaxID is the highest id matched when the operation was created, rows
+	// created afterwards are left alone
+	MaxID int64 `json:"max_id,omitempty"`
+}
+
+// IsEmpty reports whether the filter would match every row
+func (f BulkFilter) IsEmpty() bool {
+	return len(f.IDs) == 0 && f.AuthorID == 0 && f.CategoryID == 0 && f.Status == "" &&
+		f.PostID == 0 && f.From == nil && f.To == nil
+}
+
+type BulkOperation struct {
+	ID int64 `json:"id"`
+	// UserID is the admin who ran the operation
+	UserID *int64     `json:"user_id"`
+	Target string     `json:"target"`
+	Action string     `json:"action"`
+	Value  string     `json:"value,omitempty"`
+	Filter BulkFilter `json:"filter"`
+	// Background operations are processed in batches by a background job
+	Background bool   `json:"background"`
+	Status     string `json:"status"`
+	// Total is the number of rows matched when the operation was created
+	Total     int64 `json:"total"`
+	Processed int64 `json:"processed"`
+	// LastID is the id of the last processed row, processing resumes after it
+	LastID     int64   `json:"last_id"`
+	Error      string  `json:"error,omitempty"`
+	CreatedAt  string  `json:"created_at"`
+	StartedAt  *string `json:"started_at"`
+	FinishedAt *string `json:"finished_at"`
+}
+
+// BulkOperationItem records a row changed by a bulk operation along with
+// the values it had before
+type BulkOperationItem struct {
+	TargetID int64           `json:"target_id"`
+	Previous json.RawMessage `json:"previous" swaggertype:"object"`
+}
+
+type BulkOperationStore struct {
+	db *sql.DB
+}
+
+const bulkOperationSelectQuery = `
+	SELECT id, user_id, target, action, value, filter, background, status, total, processed, last_id, error,
+		created_at, started_at, finished_at
+	FROM bulk_operations
+`
+
+// bulkOperationFields returns the scan destinations of the columns selected
+// by bulkOperationSelectQuery, the filter is decoded by scanBulkFilter
+func bulkOperationFields(op *BulkOperation, filter *[]byte) []any {
+	return []any{
+		&op.ID,
+		&op.UserID,
+		&op.Target,
+		&op.Action,
+		&op.Value,
+		filter,
+		&op.Background,
+		&op.Status,
+		&op.Total,
+		&op.Processed,
+		&op.LastID,
+		&op.Error,
+		&op.CreatedAt,
+		&op.StartedAt,
+		&op.FinishedAt,
+	}
+}
+
+func scanBulkFilter(op *BulkOperation, filter []byte) error {
+	if err := json.Unmarshal(filter, &op.Filter); err != nil {
+		return fmt.Errorf("invalid filter of bulk operation %d: %w", op.ID, err)
+	}
+
+	return nil
+}
+
+func bulkTable(target string) string {
+	if target == BulkTargetComments {
+		return "comments"
+	}
+
+	return "posts"
+}
+
+// getBulkFilters builds the WHERE conditions of a filter on the rows aliased
+// t, numbered after the params already in queryParams
+func getBulkFilters(target string, f BulkFilter, queryParams []any) ([]string, []any) {
+	whereConditions := []string{}
+
+	if target == BulkTargetPosts {
+		whereConditions = append(whereConditions, "t.deleted_at IS NULL")
+	}
+
+	if len(f.IDs) > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf("t.id = ANY($%d)", len(queryParams)+1))
+		queryParams = append(queryParams, pq.Array(f.IDs))
+	}
+
+	if f.AuthorID > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf("t.user_id = $%d", len(queryParams)+1))
+		queryParams = append(queryParams, f.AuthorID)
+	}
+
+	if target == BulkTargetPosts && f.CategoryID > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf("t.category_id = $%d", len(queryParams)+1))
+		queryParams = append(queryParams, f.CategoryID)
+	}
+
+	if target == BulkTargetPosts && f.Status != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("t.status ILIKE $%d", len(queryParams)+1))
+		queryParams = append(queryParams, f.Status)
+	}
+
+	if target == BulkTargetComments && f.PostID > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf("t.post_id = $%d", len(queryParams)+1))
+		queryParams = append(queryParams, f.PostID)
+	}
+
+	if f.From != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("t.created_at >= $%d", len(queryParams)+1))
+		queryParams = append(queryParams, *f.From)
+	}
+
+	if f.To != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("t.created_at <= $%d", len(queryParams)+1))
+		queryParams = append(queryParams, *f.To)
+	}
+
+	if len(whereConditions) == 0 {
+		whereConditions = append(whereConditions, "TRUE")
+	}
+
+	return whereConditions, queryParams
+}
+
+// getBulkActionQuery returns the statement applying the action of op to the
+// next batch of rows after op.LastID. It records the previous values of the
+// rows and returns their ids. A nil limit processes every remaining row.
+func getBulkActionQuery(op *BulkOperation, limit any) (string, []any, error) {
+	whereConditions, queryParams := getBulkFilters(op.Target, op.Filter, []any{
+		op.ID,
+		op.LastID,
+		limit,
+		op.Filter.MaxID,
+	})
+	valueParam := fmt.Sprintf("$%d", len(queryParams)+1)
+
+	var previous, apply string
+	switch op.Target + ":" + op.Action {
+	case BulkTargetPosts + ":" + BulkActionDelete:
+		previous = "jsonb_build_object('title', t.title, 'status', t.status)"
+		apply = "UPDATE posts t SET deleted_at = NOW() FROM batch WHERE t.id = batch.id RETURNING t.id"
+	case BulkTargetPosts + ":" + BulkActionSetStatus:
+		previous = "jsonb_build_object('status', t.status)"
+		apply = "UPDATE posts t SET status = " + valueParam + ", updated_at = NOW(), version = t.version + 1 FROM batch WHERE t.id = batch.id RETURNING t.id"
+		queryParams = append(queryParams, op.Value)
+	case BulkTargetPosts + ":" + BulkActionSetCategory:
+		previous = "jsonb_build_object('category_id', t.category_id)"
+		apply = "UPDATE posts t SET category_id = " + valueParam + "::bigint, updated_at = NOW(), version = t.version + 1 FROM batch WHERE t.id = batch.id RETURNING t.id"
+		queryParams = append(queryParams, op.Value)
+	case BulkTargetPosts + ":" + BulkActionReassignAuthor:
+		previous = "jsonb_build_object('user_id', t.user_id)"
+		apply = "UPDATE posts t SET user_id = " + valueParam + "::bigint, updated_at = NOW(), version = t.version + 1 FROM batch WHERE t.id = batch.id RETURNING t.id"
+		queryParams = append(queryParams, op.Value)
+	case BulkTargetComments + ":" + BulkActionDelete:
+		return getBulkCommentDeleteQuery(whereConditions), queryParams, nil
+	default:
+		return "", nil, ErrInvalidBulkAction
+	}
+
+	query := `
+		WITH batch AS (
+			SELECT t.id, ` + previous + ` AS previous
+			FROM ` + bulkTable(op.Target) + ` t
+			WHERE t.id > $2 AND t.id <= $4 AND ` + strings.Join(whereConditions, " AND ") + `
+			ORDER BY t.id
+			LIMIT $3
+			FOR UPDATE
+		), logged AS (
+			INSERT INTO bulk_operation_items (operation_id, target_id, previous)
+			SELECT $1, id, previous FROM batch
+		)
+	` + apply
+
+	return query, queryParams, nil
+}
+
+// getBulkCommentDeleteQuery returns the statement deleting the next batch of
+// comments. Comments are deleted for good, and their replies with them: the
+// whole subtrees are logged with their content, the replies marked as
+// cascaded. Only the matched comments are returned, so the progress and
+// LastID of the operation only follow them.
+func getBulkCommentDeleteQuery(whereConditions []string) string {
+	return `
+		WITH RECURSIVE batch AS (
+			SELECT t.id
+			FROM comments t
+			WHERE t.id > $2 AND t.id <= $4 AND ` + strings.Join(whereConditions, " AND ") + `
+			ORDER BY t.id
+			LIMIT $3
+			FOR UPDATE
+		), subtree AS (
+			SELECT b.id, false AS cascaded FROM batch b
+			UNION
+			SELECT r.id, true FROM comments r JOIN subtree s ON r.parent_id = s.id
+		), logged AS (
+			INSERT INTO bulk_operation_items (operation_id, target_id, previous)
+			SELECT DISTINCT ON (t.id) $1, t.id, jsonb_build_object('post_id', t.post_id, 'parent_id', t.parent_id,
+				'user_id', t.user_id, 'content', t.content, 'created_at', t.created_at, 'cascaded', s.cascaded)
+			FROM comments t
+			JOIN subtree s ON s.id = t.id
+			ORDER BY t.id, s.cascaded
+		), deleted AS (
+			DELETE FROM comments t USING subtree s WHERE t.id = s.id
+		)
+		SELECT id FROM batch
+	`
+}
+
+// Count returns the number of rows matched by a filter and the highest of
+// their ids
+func (s *BulkOperationStore) Count(ctx context.Context, target string, filter BulkFilter) (int64, int64, error) {
+	whereConditions, queryParams := getBulkFilters(target, filter, []any{})
+	query := `SELECT COUNT(*), COALESCE(MAX(t.id), 0) FROM ` + bulkTable(target) + ` t WHERE ` +
+		strings.Join(whereConditions, " AND ")
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var count, maxID int64
+	if err := s.db.QueryRowContext(ctx, query, queryParams...).Scan(&count, &maxID); err != nil {
+		return 0, 0, err
+	}
+
+	return count, maxID, nil
+}
+
+// Create records a background operation, it is processed later by
+// ProcessBatch
+func (s *BulkOperationStore) Create(ctx context.Context, op *BulkOperation) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	op.Status = BulkStatusPending
+	return insertBulkOperation(ctx, s.db, op)
+}
+
+// Run records an operation and applies it to every matched row in a single
+// transaction
+func (s *BulkOperationStore) Run(ctx context.Context, op *BulkOperation) error {
+	ctx, cancel := context.WithTimeout(ctx, BatchQueryTimeoutDuration)
+	defer cancel()
+
+	return withTx(s.db, ctx, func(tx *sql.Tx) error {
+		op.Status = BulkStatusRunning
+		if err := insertBulkOperation(ctx, tx, op); err != nil {
+			return err
+		}
+
+		processed, lastID, err := processBulkBatch(ctx, tx, op, nil)
+		if err != nil {
+			return err
+		}
+
+		return finishBulkBatch(ctx, tx, op, processed, lastID, true)
+	})
+}
+
+// ProcessBatch applies an unfinished operation to its next batch of at most
+// limit rows. It returns the operation as it is afterwards and the number of
+// rows the batch changed. Each batch is a transaction of its own that moves
+// LastID, so the operation resumes from the last committed batch after an
+// interruption.
+func (s *BulkOperationStore) ProcessBatch(ctx context.Context, id int64, limit int) (*BulkOperation, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, BatchQueryTimeoutDuration)
+	defer cancel()
+
+	var op BulkOperation
+	var processed int64
+	err := withTx(s.db, ctx, func(tx *sql.Tx) error {
+		// the lock keeps concurrent workers from processing the same batch
+		var filter []byte
+		err := tx.QueryRowContext(ctx, bulkOperationSelectQuery+` WHERE id = $1 FOR UPDATE`, id).
+			Scan(bulkOperationFields(&op, &filter)...)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return err
+		}
+
+		if err := scanBulkFilter(&op, filter); err != nil {
+			return err
+		}
+
+		if op.Status != BulkStatusPending && op.Status != BulkStatusRunning {
+			return nil
+		}
+
+		var lastID int64
+		processed, lastID, err = processBulkBatch(ctx, tx, &op, limit)
+		if err != nil {
+			return err
+		}
+
+		return finishBulkBatch(ctx, tx, &op, processed, lastID, processed < int64(limit))
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &op, processed, nil
+}
+
+// GetNextUnfinished returns the id of the oldest operation that is waiting
+// to be processed, or ErrNotFound
+func (s *BulkOperationStore) GetNextUnfinished(ctx context.Context) (int64, error) {
+	query := `
+		SELECT id FROM bulk_operations
+		WHERE status IN ('pending', 'running')
+		ORDER BY id
+		LIMIT 1
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var id int64
+	if err := s.db.QueryRowContext(ctx, query).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNotFound
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// Fail stops an operation, the batches already processed are kept
+func (s *BulkOperationStore) Fail(ctx context.Context, id int64, message string) error {
+	query := `
+		UPDATE bulk_operations SET status = 'failed', error = $2, finished_at = NOW()
+		WHERE id = $1
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := s.db.ExecContext(ctx, query, id, message)
+	return err
+}
+
+// Resume queues a failed background operation again, it continues after the
+// last processed row. It returns ErrNotFound when there is no such operation.
+func (s *BulkOperationStore) Resume(ctx context.Context, id int64) error {
+	query := `
+		UPDATE bulk_operations SET status = 'pending', error = '', finished_at = NULL
+		WHERE id = $1 AND status = 'failed' AND background
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *BulkOperationStore) GetByID(ctx context.Context, id int64) (*BulkOperation, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var op BulkOperation
+	var filter []byte
+	err := s.db.QueryRowContext(ctx, bulkOperationSelectQuery+` WHERE id = $1`, id).Scan(bulkOperationFields(&op, &filter)...)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if err := scanBulkFilter(&op, filter); err != nil {
+		return nil, err
+	}
+
+	return &op, nil
+}
+
+// Get lists the operations, most recent first
+func (s *BulkOperationStore) Get(ctx context.Context, page, limit int) ([]BulkOperation, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var total int64
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM bulk_operations`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, bulkOperationSelectQuery+` ORDER BY id DESC LIMIT $1 OFFSET $2`, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	operations := []BulkOperation{}
+	for rows.Next() {
+		var op BulkOperation
+		var filter []byte
+		if err := rows.Scan(bulkOperationFields(&op, &filter)...); err != nil {
+			return nil, 0, err
+		}
+
+		if err := scanBulkFilter(&op, filter); err != nil {
+			return nil, 0, err
+		}
+
+		operations = append(operations, op)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return operations, total, nil
+}
+
+// GetItems lists the rows changed by an operation in the order they were
+// processed
+func (s *BulkOperationStore) GetItems(ctx context.Context, id int64, page, limit int) ([]BulkOperationItem, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var total int64
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM bulk_operation_items WHERE operation_id = $1`, id).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT target_id, previous
+		FROM bulk_operation_items
+		WHERE operation_id = $1
+		ORDER BY target_id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := s.db.QueryContext(ctx, query, id, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []BulkOperationItem{}
+	for rows.Next() {
+		var item BulkOperationItem
+		if err := rows.Scan(&item.TargetID, &item.Previous); err != nil {
+			return nil, 0, err
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+// queryRower is implemented by both *sql.DB and *sql.Tx
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func insertBulkOperation(ctx context.Context, db queryRower, op *BulkOperation) error {
+	filter, err := json.Marshal(op.Filter)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO bulk_operations (user_id, target, action, value, filter, background, status, total, started_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, CASE WHEN $7 = 'running' THEN NOW() END)
+		RETURNING id, created_at, started_at
+	`
+
+	return db.QueryRowContext(
+		ctx,
+		query,
+		op.UserID,
+		op.Target,
+		op.Action,
+		op.Value,
+		filter,
+		op.Background,
+		op.Status,
+		op.Total,
+	).Scan(&op.ID, &op.CreatedAt, &op.StartedAt)
+}
+
+// processBulkBatch applies op to its next batch of rows and returns how many
+// rows it changed and the highest of their ids
+func processBulkBatch(ctx context.Context, tx *sql.Tx, op *BulkOperation, limit any) (int64, int64, error) {
+	query, queryParams, err := getBulkActionQuery(op, limit)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	rows, err := tx.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer rows.Close()
+
+	var processed, lastID int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return 0, 0, err
+		}
+
+		processed++
+		lastID = max(lastID, id)
+	}
+
+	return processed, lastID, rows.Err()
+}
+
+func finishBulkBatch(ctx context.Context, tx *sql.Tx, op *BulkOperation, processed, lastID int64, done bool) error {
+	status := BulkStatusRunning
+	if done {
+		status = BulkStatusCompleted
+	}
+
+	query := `
+		UPDATE bulk_operations
+		SET processed = processed + $2, last_id = GREATEST(last_id, $3), status = $4::text,
+			started_at = COALESCE(started_at, NOW()),
+			finished_at = CASE WHEN $4::text = 'completed' THEN NOW() END
+		WHERE id = $1
+		RETURNING processed, last_id, status, started_at, finished_at
+	`
+
+	return tx.QueryRowContext(ctx, query, op.ID, processed, lastID, status).Scan(
+		&op.Processed,
+		&op.LastID,
+		&op.Status,
+		&op.StartedAt,
+		&op.FinishedAt,
+	)
+}
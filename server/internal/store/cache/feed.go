@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+type FeedStore struct {
+	redisDB *redis.Client
+	expTime time.Duration
+}
+
+func (s *FeedStore) GetTotal(ctx context.Context, fq store.PaginatedFeedQuery) (*int64, error) {
+	if s.redisDB == nil {
+		return nil, ErrRedisNotInit
+	}
+
+	data, err := s.redisDB.Get(ctx, feedTotalCacheKey(fq)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("redis get error: %w", err)
+	}
+
+	total, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse feed total: %w", err)
+	}
+
+	return &total, nil
+}
+
+func (s *FeedStore) SetTotal(ctx context.Context, fq store.PaginatedFeedQuery, total int64) error {
+	if s.redisDB == nil {
+		return ErrRedisNotInit
+	}
+
+	if err := s.redisDB.SetEX(ctx, feedTotalCacheKey(fq), total, s.expTime).Err(); err != nil {
+		return fmt.Errorf("redis set error: %w", err)
+	}
+
+	return nil
+}
+
+// feedTotalCacheKey only depends on the filters, not on the page or cursor
+func feedTotalCacheKey(fq store.PaginatedFeedQuery) string {
+	filters := fmt.Sprintf("%s\x00%s\x00%s\x00%s\x00%d\x00%d\x00%t\x00%s\x00%d\x00%d\x00%s",
+		fq.Category, fq.Status, fq.Search, fq.GetWindowInterval(), fq.FollowerID, fq.ViewerID, fq.ViewerCanSeeDrafts,
+		fq.Author, fq.From.UnixMicro(), fq.To.UnixMicro(), fq.Locale)
+	hash := sha256.Sum256([]byte(filters))
+	return "feed:total:" + hex.EncodeToString(hash[:8])
+}
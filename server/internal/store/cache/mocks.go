@@ -2,13 +2,17 @@ package cache
 
 import (
 	"context"
+	"time"
 
 	"github.com/ritchie-gr8/my-blog-app/internal/store"
 )
 
 func NewMockStore() Storage {
 	return Storage{
-		Users: &MockUserStore{},
+		Users:   &MockUserStore{},
+		Feed:    &MockFeedStore{},
+		Views:   &MockViewStore{},
+		Related: &MockRelatedStore{},
 	}
 }
 
@@ -25,3 +29,41 @@ func (s *MockUserStore) Set(context.Context, *store.User) error {
 func (s *MockUserStore) Delete(context.Context, int64) error {
 	return nil
 }
+
+type MockFeedStore struct{}
+
+func (s *MockFeedStore) GetTotal(context.Context, store.PaginatedFeedQuery) (*int64, error) {
+	return nil, nil
+}
+
+func (s *MockFeedStore) SetTotal(context.Context, store.PaginatedFeedQuery, int64) error {
+	return nil
+}
+
+type MockViewStore struct{}
+
+func (s *MockViewStore) MarkViewed(context.Context, int64, string, time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (s *MockViewStore) Increment(context.Context, store.PostViewKey) error {
+	return nil
+}
+
+func (s *MockViewStore) Drain(context.Context) (map[store.PostViewKey]int64, error) {
+	return map[store.PostViewKey]int64{}, nil
+}
+
+type MockRelatedStore struct{}
+
+func (s *MockRelatedStore) Get(context.Context, int64) ([]store.RelatedPost, int64, error) {
+	return nil, 0, nil
+}
+
+func (s *MockRelatedStore) Set(context.Context, int64, int64, []store.RelatedPost) error {
+	return nil
+}
+
+func (s *MockRelatedStore) Invalidate(context.Context) error {
+	return nil
+}
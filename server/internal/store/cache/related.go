@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+const relatedVersionKey = "related:version"
+
+// RelatedStore caches the related posts of each post. Any post change can
+// affect many lists, so instead of tracking them all the keys are namespaced
+// by a version that Invalidate bumps, and stale entries simply expire.
+type RelatedStore struct {
+	redisDB *redis.Client
+	expTime time.Duration
+}
+
+// Get returns the cached related posts (nil on a miss) along with the cache
+// version they were looked up with, to be passed back to Set
+func (s *RelatedStore) Get(ctx context.Context, postID int64) ([]store.RelatedPost, int64, error) {
+	if s.redisDB == nil {
+		return nil, 0, ErrRedisNotInit
+	}
+
+	version, err := s.version(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	data, err := s.redisDB.Get(ctx, relatedCacheKey(version, postID)).Result()
+	if err == redis.Nil {
+		return nil, version, nil
+	} else if err != nil {
+		return nil, version, fmt.Errorf("redis get error: %w", err)
+	}
+
+	var posts []store.RelatedPost
+	if err := json.Unmarshal([]byte(data), &posts); err != nil {
+		return nil, version, fmt.Errorf("failed to unmarshal related posts: %w", err)
+	}
+
+	return posts, version, nil
+}
+
+// Set stores the related posts under the version returned by Get, so a result
+// computed before an invalidation never shows up after it
+func (s *RelatedStore) Set(ctx context.Context, postID, version int64, posts []store.RelatedPost) error {
+	if s.redisDB == nil {
+		return ErrRedisNotInit
+	}
+
+	data, err := json.Marshal(posts)
+	if err != nil {
+		return fmt.Errorf("failed to marshal related posts: %w", err)
+	}
+
+	if err := s.redisDB.SetEX(ctx, relatedCacheKey(version, postID), data, s.expTime).Err(); err != nil {
+		return fmt.Errorf("redis set error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *RelatedStore) Invalidate(ctx context.Context) error {
+	if s.redisDB == nil {
+		return ErrRedisNotInit
+	}
+
+	if err := s.redisDB.Incr(ctx, relatedVersionKey).Err(); err != nil {
+		return fmt.Errorf("redis incr error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *RelatedStore) version(ctx context.Context) (int64, error) {
+	data, err := s.redisDB.Get(ctx, relatedVersionKey).Result()
+	if err == redis.Nil {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("redis get error: %w", err)
+	}
+
+	version, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse related version: %w", err)
+	}
+
+	return version, nil
+}
+
+func relatedCacheKey(version, postID int64) string {
+	return fmt.Sprintf("related:%d:%d", version, postID)
+}
@@ -14,9 +14,30 @@ type Storage struct {
 		Set(context.Context, *store.User) error
 		Delete(context.Context, int64) error
 	}
+
+	Feed interface {
+		GetTotal(context.Context, store.PaginatedFeedQuery) (*int64, error)
+		SetTotal(context.Context, store.PaginatedFeedQuery, int64) error
+	}
+
+	Views interface {
+		MarkViewed(ctx context.Context, postID int64, viewer string, window time.Duration) (bool, error)
+		Increment(context.Context, store.PostViewKey) error
+		Drain(context.Context) (map[store.PostViewKey]int64, error)
+	}
+
+	Related interface {
+		Get(ctx context.Context, postID int64) ([]store.RelatedPost, int64, error)
+		Set(ctx context.Context, postID, version int64, posts []store.RelatedPost) error
+		Invalidate(context.Context) error
+	}
 }
 
-const UserExpTime = time.Minute
+const (
+	UserExpTime      = time.Minute
+	FeedTotalExpTime = time.Second * 30
+	RelatedExpTime   = time.Minute * 10
+)
 
 func NewRedisStore(redisDB *redis.Client) Storage {
 	return Storage{
@@ -24,5 +45,16 @@ func NewRedisStore(redisDB *redis.Client) Storage {
 			redisDB: redisDB,
 			expTime: UserExpTime,
 		},
+		Feed: &FeedStore{
+			redisDB: redisDB,
+			expTime: FeedTotalExpTime,
+		},
+		Views: &ViewStore{
+			redisDB: redisDB,
+		},
+		Related: &RelatedStore{
+			redisDB: redisDB,
+			expTime: RelatedExpTime,
+		},
 	}
 }
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/ritchie-gr8/my-blog-app/internal/store"
+)
+
+const viewsBufferKey = "views:buffer"
+
+type ViewStore struct {
+	redisDB *redis.Client
+}
+
+// MarkViewed reports whether viewer is seeing the post for the first time
+// within window. Later calls in the same window return false.
+func (s *ViewStore) MarkViewed(ctx context.Context, postID int64, viewer string, window time.Duration) (bool, error) {
+	if s.redisDB == nil {
+		return false, ErrRedisNotInit
+	}
+
+	first, err := s.redisDB.SetNX(ctx, viewSeenCacheKey(postID, viewer), 1, window).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis setnx error: %w", err)
+	}
+
+	return first, nil
+}
+
+// Increment buffers a view until the next Drain
+func (s *ViewStore) Increment(ctx context.Context, key store.PostViewKey) error {
+	if s.redisDB == nil {
+		return ErrRedisNotInit
+	}
+
+	field := fmt.Sprintf("%d:%s", key.PostID, key.Day)
+	if err := s.redisDB.HIncrBy(ctx, viewsBufferKey, field, 1).Err(); err != nil {
+		return fmt.Errorf("redis hincrby error: %w", err)
+	}
+
+	return nil
+}
+
+// Drain takes the buffered views out of redis. The buffer is renamed first so
+// views counted while draining go to a fresh buffer.
+func (s *ViewStore) Drain(ctx context.Context) (map[store.PostViewKey]int64, error) {
+	if s.redisDB == nil {
+		return nil, ErrRedisNotInit
+	}
+
+	draining := fmt.Sprintf("%s:draining:%d", viewsBufferKey, time.Now().UnixNano())
+	if err := s.redisDB.Rename(ctx, viewsBufferKey, draining).Err(); err != nil {
+		if strings.Contains(err.Error(), "no such key") {
+			return map[store.PostViewKey]int64{}, nil
+		}
+		return nil, fmt.Errorf("redis rename error: %w", err)
+	}
+
+	fields, err := s.redisDB.HGetAll(ctx, draining).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis hgetall error: %w", err)
+	}
+
+	if err := s.redisDB.Del(ctx, draining).Err(); err != nil {
+		return nil, fmt.Errorf("redis del error: %w", err)
+	}
+
+	counts := make(map[store.PostViewKey]int64, len(fields))
+	for field, value := range fields {
+		id, day, ok := strings.Cut(field, ":")
+		if !ok {
+			continue
+		}
+
+		postID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		counts[store.PostViewKey{PostID: postID, Day: day}] += n
+	}
+
+	return counts, nil
+}
+
+func viewSeenCacheKey(postID int64, viewer string) string {
+	return fmt.Sprintf("views:seen:%d:%s", postID, viewer)
+}
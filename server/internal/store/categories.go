@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -14,6 +15,9 @@ import (
 type Category struct {
 	ID   int64  `json:"id"`
 	Name string `json:"name"`
+	// RequiresReview makes posts of the category go through the editorial
+	// review before they are published
+	RequiresReview bool `json:"requires_review"`
 }
 
 type CategoryStore struct {
@@ -28,21 +32,19 @@ type PaginatedCategoryQuery struct {
 
 func (s *CategoryStore) Create(ctx context.Context, category *Category) error {
 	query := `
-		INSERT INTO categories (name)
-		VALUES ($1)
+		INSERT INTO categories (name, requires_review)
+		VALUES ($1, $2)
 		RETURNING id
 	`
 
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
 	defer cancel()
 
-	if err := s.db.QueryRowContext(ctx, query, category.Name).Scan(&category.ID); err != nil {
+	if err := s.db.QueryRowContext(ctx, query, category.Name, category.RequiresReview).Scan(&category.ID); err != nil {
+		var pqErr *pq.Error
 		switch {
-		case err.(*pq.Error) != nil:
-			pqErr := err.(*pq.Error)
-			if pqErr.Code == "23505" {
-				return ErrUniqueViolation
-			}
+		case errors.As(err, &pqErr) && pqErr.Code == "23505":
+			return ErrUniqueViolation
 		default:
 			return err
 		}
@@ -53,7 +55,7 @@ func (s *CategoryStore) Create(ctx context.Context, category *Category) error {
 
 func (s *CategoryStore) GetAll(ctx context.Context) ([]*Category, error) {
 	query := `
-		SELECT id, name
+		SELECT id, name, requires_review
 		FROM categories
 		ORDER BY name
 	`
@@ -72,7 +74,7 @@ func (s *CategoryStore) GetAll(ctx context.Context) ([]*Category, error) {
 
 	for rows.Next() {
 		var category Category
-		if err := rows.Scan(&category.ID, &category.Name); err != nil {
+		if err := rows.Scan(&category.ID, &category.Name, &category.RequiresReview); err != nil {
 			return nil, err
 		}
 
@@ -111,7 +113,7 @@ func (s *CategoryStore) Get(ctx context.Context, query *PaginatedCategoryQuery)
 
 	for rows.Next() {
 		var category Category
-		if err := rows.Scan(&category.ID, &category.Name); err != nil {
+		if err := rows.Scan(&category.ID, &category.Name, &category.RequiresReview); err != nil {
 			return nil, 0, err
 		}
 
@@ -127,7 +129,7 @@ func (s *CategoryStore) Get(ctx context.Context, query *PaginatedCategoryQuery)
 
 func (s *CategoryStore) GetByID(ctx context.Context, id int64) (*Category, error) {
 	query := `
-		SELECT id, name
+		SELECT id, name, requires_review
 		FROM categories
 		WHERE id = $1
 	`
@@ -136,7 +138,7 @@ func (s *CategoryStore) GetByID(ctx context.Context, id int64) (*Category, error
 	defer cancel()
 
 	var category Category
-	if err := s.db.QueryRowContext(ctx, query, id).Scan(&category.ID, &category.Name); err != nil {
+	if err := s.db.QueryRowContext(ctx, query, id).Scan(&category.ID, &category.Name, &category.RequiresReview); err != nil {
 		switch err {
 		case sql.ErrNoRows:
 			return nil, ErrNotFound
@@ -173,30 +175,39 @@ func (s *CategoryStore) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// Update renames a category and sets whether it requires review
 func (s *CategoryStore) Update(ctx context.Context, category *Category) error {
 	query := `
-		UPDATE categories SET name = $1 WHERE id = $2
+		UPDATE categories SET name = $1, requires_review = $2 WHERE id = $3
 	`
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
 	defer cancel()
 
-	_, err := s.db.ExecContext(
+	res, err := s.db.ExecContext(
 		ctx, query,
 		category.Name,
+		category.RequiresReview,
 		category.ID,
 	)
 	if err != nil {
+		var pqErr *pq.Error
 		switch {
-		case err.(*pq.Error) != nil:
-			pqErr := err.(*pq.Error)
-			if pqErr.Code == "23505" {
-				return ErrUniqueViolation
-			}
+		case errors.As(err, &pqErr) && pqErr.Code == "23505":
+			return ErrUniqueViolation
 		default:
 			return err
 		}
 	}
 
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
 	return nil
 }
 
@@ -223,7 +234,7 @@ func getCategoriesCountQuery(query *PaginatedCategoryQuery) (string, []any) {
 
 func getCategoriesQuery(query *PaginatedCategoryQuery) (string, []any) {
 	queryString := `
-		SELECT id, name
+		SELECT id, name, requires_review
 		FROM categories
 	`
 
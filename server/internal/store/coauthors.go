@@ -0,0 +1,186 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// Co-author permissions. Editors can update a post, viewers can only read it
+// while it is a draft. Neither can delete it.
+const (
+	CoAuthorEditor = "editor"
+	CoAuthorViewer = "viewer"
+)
+
+type CoAuthor struct {
+	PostID         int64   `json:"post_id"`
+	UserID         int64   `json:"user_id"`
+	Permission     string  `json:"permission"`
+	Name           string  `json:"name"`
+	Username       string  `json:"username"`
+	ProfilePicture string  `json:"profile_picture"`
+	InvitedBy      *int64  `json:"invited_by"`
+	AcceptedAt     *string `json:"accepted_at"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+// CoAuthorPermission returns the permission of an accepted co-author of the
+// post, or an empty string when userID is not one
+func (p *Post) CoAuthorPermission(userID int64) string {
+	for _, author := range p.CoAuthors {
+		if author.ID == userID {
+			return author.Permission
+		}
+	}
+
+	return ""
+}
+
+type CoAuthorStore struct {
+	db *sql.DB
+}
+
+// Invite adds a pending co-author. Inviting someone twice returns
+// ErrUniqueViolation.
+func (s *CoAuthorStore) Invite(ctx context.Context, coAuthor *CoAuthor) error {
+	query := `
+		INSERT INTO post_coauthors (post_id, user_id, permission, invited_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	err := s.db.QueryRowContext(ctx, query, coAuthor.PostID, coAuthor.UserID, coAuthor.Permission, coAuthor.InvitedBy).Scan(&coAuthor.CreatedAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return ErrUniqueViolation
+	}
+
+	return err
+}
+
+// Accept accepts a pending invitation, it returns ErrNotFound when there is none
+func (s *CoAuthorStore) Accept(ctx context.Context, postID, userID int64) error {
+	query := `
+		UPDATE post_coauthors SET accepted_at = NOW()
+		WHERE post_id = $1 AND user_id = $2 AND accepted_at IS NULL
+	`
+	return s.exec(ctx, query, postID, userID)
+}
+
+func (s *CoAuthorStore) UpdatePermission(ctx context.Context, postID, userID int64, permission string) error {
+	query := `
+		UPDATE post_coauthors SET permission = $3
+		WHERE post_id = $1 AND user_id = $2
+	`
+	return s.exec(ctx, query, postID, userID, permission)
+}
+
+// Remove removes a co-author or withdraws a pending invitation
+func (s *CoAuthorStore) Remove(ctx context.Context, postID, userID int64) error {
+	query := `DELETE FROM post_coauthors WHERE post_id = $1 AND user_id = $2`
+	return s.exec(ctx, query, postID, userID)
+}
+
+// GetByPostID returns the co-authors of a post, pending invitations included
+func (s *CoAuthorStore) GetByPostID(ctx context.Context, postID int64) ([]CoAuthor, error) {
+	query := `
+		SELECT pc.post_id, pc.user_id, pc.permission, u.name, u.username, COALESCE(u.profile_picture, ''),
+			pc.invited_by, pc.accepted_at, pc.created_at
+		FROM post_coauthors pc
+		JOIN users u ON u.id = pc.user_id
+		WHERE pc.post_id = $1
+		ORDER BY pc.created_at, pc.user_id
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	coAuthors := []CoAuthor{}
+	for rows.Next() {
+		var c CoAuthor
+		err := rows.Scan(
+			&c.PostID,
+			&c.UserID,
+			&c.Permission,
+			&c.Name,
+			&c.Username,
+			&c.ProfilePicture,
+			&c.InvitedBy,
+			&c.AcceptedAt,
+			&c.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		coAuthors = append(coAuthors, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return coAuthors, nil
+}
+
+func (s *CoAuthorStore) exec(ctx context.Context, query string, args ...any) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// getAcceptedCoAuthors loads the co-authors listed on a post
+func getAcceptedCoAuthors(ctx context.Context, db *sql.DB, postID int64) ([]Author, error) {
+	query := `
+		SELECT u.id, u.name, u.bio, u.profile_picture, pc.permission
+		FROM post_coauthors pc
+		JOIN users u ON u.id = pc.user_id
+		WHERE pc.post_id = $1 AND pc.accepted_at IS NOT NULL
+		ORDER BY pc.accepted_at, pc.user_id
+	`
+	rows, err := db.QueryContext(ctx, query, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	authors := []Author{}
+	for rows.Next() {
+		var a Author
+		var bio, profilePicture sql.NullString
+		if err := rows.Scan(&a.ID, &a.Name, &bio, &profilePicture, &a.Permission); err != nil {
+			return nil, err
+		}
+		a.Bio = bio.String
+		a.ProfilePicture = profilePicture.String
+
+		authors = append(authors, a)
+	}
+
+	return authors, rows.Err()
+}
@@ -3,34 +3,105 @@ package store
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 )
 
+// MaxCommentDepth is the deepest a reply can be nested, comments on the post
+// itself are at depth 0
+const MaxCommentDepth = 4
+
+// DeletedCommentContent replaces the content of the deleted comments that are
+// kept because they have replies
+const DeletedCommentContent = "[deleted]"
+
+// commentColumns selects a comment and its author, see commentFields. The
+// author of a deleted comment is hidden. viewer is the query parameter of
+// the user user_has_liked is computed for.
+func commentColumns(viewer string) string {
+	return `
+		c.id, c.post_id, CASE WHEN c.deleted_at IS NULL THEN c.user_id ELSE 0 END, c.parent_id, c.depth,
+		c.replies_count, c.likes_count,
+		EXISTS(SELECT 1 FROM comment_likes cl WHERE cl.comment_id = c.id AND cl.user_id = ` + viewer + `),
+		CASE WHEN c.deleted_at IS NULL THEN c.content ELSE '` + DeletedCommentContent + `' END,
+		c.created_at, c.edited_at, c.deleted_at IS NOT NULL,
+		COALESCE(users.username, ''), COALESCE(users.id, 0), COALESCE(users.profile_picture, '')
+	`
+}
+
+// Orders of the comments on a post, see GetPage. Replies are always listed
+// oldest first.
+const (
+	CommentSortNewest = "newest"
+	CommentSortOldest = "oldest"
+	// CommentSortTop lists the comments with the most likes first
+	CommentSortTop = "top"
+)
+
+// CommentCursor is the decoded keyset position of a page of comments, the
+// last comment on the post of the previous page
+type CommentCursor struct {
+	Sort      string `json:"s"`
+	CreatedAt string `json:"t,omitempty"`
+	Score     int64  `json:"k,omitempty"`
+	ID        int64  `json:"i"`
+}
+
 type Comment struct {
-	ID        int64  `json:"id"`
-	PostID    int64  `json:"post_id"`
-	UserID    int64  `json:"user_id"`
-	Content   string `json:"content"`
-	CreatedAt string `json:"created_at"`
-	User      User   `json:"user"`
+	ID     int64 `json:"id"`
+	PostID int64 `json:"post_id"`
+	UserID int64 `json:"user_id"`
+	// ParentID is the comment this one replies to, nil for comments on the
+	// post itself
+	ParentID     *int64 `json:"parent_id"`
+	Depth        int    `json:"depth"`
+	RepliesCount int64  `json:"replies_count"`
+	// LikesCount is the score of the comment
+	LikesCount   int64  `json:"likes_count"`
+	UserHasLiked bool   `json:"user_has_liked"`
+	Content      string `json:"content"`
+	CreatedAt    string `json:"created_at"`
+	// EditedAt is the time of the last edit, the previous contents are kept
+	// as CommentEdits
+	EditedAt *string `json:"edited_at"`
+	// Deleted is set on the tombstones of deleted comments that have replies
+	Deleted bool `json:"deleted"`
+	User    User `json:"user"`
+	// Replies is only filled when the comments are returned as a tree
+	Replies []Comment `json:"replies,omitempty"`
 }
 
 type CommentStore struct {
 	db *sql.DB
 }
 
-func (s *CommentStore) GetByPostID(ctx context.Context, postID int64) ([]Comment, error) {
+// GetByPostID returns the comments of a post in thread order: the comments on
+// the post newest first, each followed by its replies oldest first, depth
+// first. user_has_liked is set for viewerID.
+func (s *CommentStore) GetByPostID(ctx context.Context, postID, viewerID int64) ([]Comment, error) {
+	// the path of a reply extends the path of its parent, sorting on it
+	// lists every comment right after the one it answers
 	query := `
-		SELECT c.id, c.post_id, c.user_id, c.content, c.created_at, users.username, users.id, users.profile_picture
-		FROM comments c
-		JOIN users ON users.id = c.user_id
-		WHERE c.post_id = $1
-		ORDER BY c.created_at DESC;
+		WITH RECURSIVE thread AS (
+			SELECT c.id, ARRAY[ROW_NUMBER() OVER (ORDER BY c.created_at DESC, c.id DESC)] AS path
+			FROM comments c
+			WHERE c.post_id = $1 AND c.parent_id IS NULL
+			UNION ALL
+			SELECT r.id, t.path || r.id
+			FROM comments r
+			JOIN thread t ON r.parent_id = t.id
+		)
+		SELECT ` + commentColumns("$2") + `
+		FROM thread t
+		JOIN comments c ON c.id = t.id
+		LEFT JOIN users ON users.id = c.user_id AND c.deleted_at IS NULL
+		ORDER BY t.path;
 	`
 
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
 	defer cancel()
 
-	rows, err := s.db.QueryContext(ctx, query, postID)
+	rows, err := s.db.QueryContext(ctx, query, postID, viewerID)
 	if err != nil {
 		return nil, err
 	}
@@ -40,32 +111,163 @@ func (s *CommentStore) GetByPostID(ctx context.Context, postID int64) ([]Comment
 
 	for rows.Next() {
 		var c Comment
-		c.User = User{}
-		err := rows.Scan(
-			&c.ID,
-			&c.PostID,
-			&c.UserID,
-			&c.Content,
-			&c.CreatedAt,
-			&c.User.Username,
-			&c.User.ID,
-			&c.User.ProfilePicture,
+		if err := rows.Scan(commentFields(&c)...); err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, c)
+	}
+
+	return comments, rows.Err()
+}
+
+// GetPage returns a page of the comments on a post in the order of sort,
+// each followed by all its replies like GetByPostID does. limit counts the
+// comments on the post only. It reports whether there are more after the
+// page.
+func (s *CommentStore) GetPage(ctx context.Context, postID, viewerID int64, sort string, cursor *CommentCursor, limit int) ([]Comment, bool, error) {
+	var order, after string
+	var afterKey any
+	var afterID int64
+
+	switch sort {
+	case CommentSortNewest:
+		order = "c.created_at DESC, c.id DESC"
+		after = "($2::timestamptz IS NULL OR (c.created_at, c.id) < ($2::timestamptz, $3))"
+		afterKey = sql.NullString{}
+	case CommentSortOldest:
+		order = "c.created_at, c.id"
+		after = "($2::timestamptz IS NULL OR (c.created_at, c.id) > ($2::timestamptz, $3))"
+		afterKey = sql.NullString{}
+	case CommentSortTop:
+		order = "c.likes_count DESC, c.id DESC"
+		after = "($2::bigint IS NULL OR (c.likes_count, c.id) < ($2::bigint, $3))"
+		afterKey = sql.NullInt64{}
+	default:
+		return nil, false, fmt.Errorf("unknown comment sort %q", sort)
+	}
+
+	if cursor != nil {
+		afterID = cursor.ID
+		if sort == CommentSortTop {
+			afterKey = sql.NullInt64{Int64: cursor.Score, Valid: true}
+		} else {
+			afterKey = sql.NullString{String: cursor.CreatedAt, Valid: true}
+		}
+	}
+
+	// one more comment on the post than asked for is read to know whether
+	// there is a next page, it is dropped below along with its replies
+	query := `
+		WITH RECURSIVE page AS (
+			SELECT c.id, ROW_NUMBER() OVER (ORDER BY ` + order + `) AS rn
+			FROM comments c
+			WHERE c.post_id = $1 AND c.parent_id IS NULL AND ` + after + `
+			ORDER BY ` + order + `
+			LIMIT $4
+		), thread AS (
+			SELECT pg.id, ARRAY[pg.rn] AS path
+			FROM page pg
+			UNION ALL
+			SELECT r.id, t.path || r.id
+			FROM comments r
+			JOIN thread t ON r.parent_id = t.id
 		)
+		SELECT ` + commentColumns("$5") + `
+		FROM thread t
+		JOIN comments c ON c.id = t.id
+		LEFT JOIN users ON users.id = c.user_id AND c.deleted_at IS NULL
+		ORDER BY t.path;
+	`
 
-		if err != nil {
-			return nil, err
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, postID, afterKey, afterID, limit+1, viewerID)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	comments := []Comment{}
+	roots := 0
+	hasMore := false
+
+	for rows.Next() {
+		var c Comment
+		if err := rows.Scan(commentFields(&c)...); err != nil {
+			return nil, false, err
+		}
+
+		if c.ParentID == nil {
+			roots++
+			if roots > limit {
+				hasMore = true
+				break
+			}
 		}
 
 		comments = append(comments, c)
 	}
 
-	return comments, nil
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	return comments, hasMore, nil
+}
+
+// GetByID returns a comment, user_has_liked is left unset
+func (s *CommentStore) GetByID(ctx context.Context, id int64) (*Comment, error) {
+	query := `
+		SELECT ` + commentColumns("0") + `
+		FROM comments c
+		LEFT JOIN users ON users.id = c.user_id AND c.deleted_at IS NULL
+		WHERE c.id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var c Comment
+	if err := s.db.QueryRowContext(ctx, query, id).Scan(commentFields(&c)...); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &c, nil
+}
+
+// commentFields returns the scan destinations of the columns selected by
+// commentColumns, in order
+func commentFields(c *Comment) []any {
+	return []any{
+		&c.ID,
+		&c.PostID,
+		&c.UserID,
+		&c.ParentID,
+		&c.Depth,
+		&c.RepliesCount,
+		&c.LikesCount,
+		&c.UserHasLiked,
+		&c.Content,
+		&c.CreatedAt,
+		&c.EditedAt,
+		&c.Deleted,
+		&c.User.Username,
+		&c.User.ID,
+		&c.User.ProfilePicture,
+	}
 }
 
 func (s *CommentStore) Create(ctx context.Context, comment *Comment) error {
 	query := `
-		INSERT INTO comments (post_id, user_id, content)
-		VALUES ($1, $2, $3)
+		INSERT INTO comments (post_id, user_id, content, parent_id, depth)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id, created_at
 	`
 
@@ -79,6 +281,8 @@ func (s *CommentStore) Create(ctx context.Context, comment *Comment) error {
 		comment.PostID,
 		comment.UserID,
 		comment.Content,
+		comment.ParentID,
+		comment.Depth,
 	).Scan(
 		&comment.ID,
 		&comment.CreatedAt,
@@ -88,3 +292,142 @@ func (s *CommentStore) Create(ctx context.Context, comment *Comment) error {
 
 	return nil
 }
+
+// CommentEdit is the content a comment had before an edit
+type CommentEdit struct {
+	ID        int64 `json:"id"`
+	CommentID int64 `json:"comment_id"`
+	// UserID is who edited the comment, its author or a moderator
+	UserID    *int64 `json:"user_id"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Update replaces the content of a comment and keeps the previous one in its
+// edit history. It returns ErrNotFound when the comment is missing or
+// deleted.
+func (s *CommentStore) Update(ctx context.Context, comment *Comment, editorID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return withTx(s.db, ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO comment_edits (comment_id, user_id, content)
+			SELECT id, $2, content FROM comments WHERE id = $1 AND deleted_at IS NULL
+		`, comment.ID, editorID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			UPDATE comments SET content = $2, edited_at = NOW()
+			WHERE id = $1 AND deleted_at IS NULL
+			RETURNING edited_at
+		`, comment.ID, comment.Content).Scan(&comment.EditedAt)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return ErrNotFound
+			default:
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Delete removes a comment. A comment with replies is kept as a tombstone
+// instead, its content and edit history are cleared, and tombstone reports
+// so. Removing the last reply of a tombstone removes the tombstone too.
+func (s *CommentStore) Delete(ctx context.Context, id int64) (tombstone bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	err = withTx(s.db, ctx, func(tx *sql.Tx) error {
+		var parentID sql.NullInt64
+		err := tx.QueryRowContext(ctx, `
+			DELETE FROM comments WHERE id = $1 AND deleted_at IS NULL AND replies_count = 0
+			RETURNING parent_id
+		`, id).Scan(&parentID)
+		if errors.Is(err, sql.ErrNoRows) {
+			tombstone = true
+			return tombstoneComment(ctx, tx, id)
+		}
+		if err != nil {
+			return err
+		}
+
+		// the replies count of the parent is updated by a trigger, a
+		// tombstone left without replies goes away
+		for parentID.Valid {
+			err := tx.QueryRowContext(ctx, `
+				DELETE FROM comments WHERE id = $1 AND deleted_at IS NOT NULL AND replies_count = 0
+				RETURNING parent_id
+			`, parentID.Int64).Scan(&parentID)
+			if errors.Is(err, sql.ErrNoRows) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return tombstone, err
+}
+
+func tombstoneComment(ctx context.Context, tx *sql.Tx, id int64) error {
+	res, err := tx.ExecContext(ctx, `
+		UPDATE comments SET content = '', deleted_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM comment_edits WHERE comment_id = $1`, id)
+	return err
+}
+
+// GetEdits returns the edit history of a comment, oldest first
+func (s *CommentStore) GetEdits(ctx context.Context, commentID int64) ([]CommentEdit, error) {
+	query := `
+		SELECT id, comment_id, user_id, content, created_at
+		FROM comment_edits
+		WHERE comment_id = $1
+		ORDER BY id
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, commentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	edits := []CommentEdit{}
+	for rows.Next() {
+		var edit CommentEdit
+		if err := rows.Scan(&edit.ID, &edit.CommentID, &edit.UserID, &edit.Content, &edit.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		edits = append(edits, edit)
+	}
+
+	return edits, rows.Err()
+}
@@ -0,0 +1,23 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+)
+
+// ContentVersionStore reads the version the database triggers bump on every
+// write to the content of the posts, their comments and categories. The
+// version is a sequence, it moves when the write happens rather than when it
+// is committed.
+type ContentVersionStore struct {
+	db *sql.DB
+}
+
+func (s *ContentVersionStore) Get(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var version int64
+	err := s.db.QueryRowContext(ctx, `SELECT last_value FROM content_version`).Scan(&version)
+	return version, err
+}
@@ -0,0 +1,213 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// ErrDraftConflict is returned when a draft was saved by someone else since
+// the revision a save or a publish is based on
+var ErrDraftConflict = errors.New("the draft was saved since this revision")
+
+// PostDraft is the working copy of a post. Saving it leaves the post as it
+// is, publishing copies the draft onto the post and removes it.
+type PostDraft struct {
+	PostID int64  `json:"post_id"`
+	UserID *int64 `json:"user_id"`
+	// Revision is incremented by every save, saves and publishes must send
+	// the revision they are based on
+	Revision int `json:"revision"`
+	// BaseVersion is the version of the post the draft was started from
+	BaseVersion      int      `json:"base_version"`
+	Title            string   `json:"title"`
+	Introduction     string   `json:"introduction"`
+	Content          string   `json:"content"`
+	CategoryID       int64    `json:"category_id"`
+	ThumbnailImage   string   `json:"thumbnail_image"`
+	ThumbnailMediaID *int64   `json:"thumbnail_media_id"`
+	Tags             []string `json:"tags"`
+	CreatedAt        string   `json:"created_at"`
+	UpdatedAt        string   `json:"updated_at"`
+}
+
+// NewPostDraft starts a working copy of post
+func NewPostDraft(post *Post) *PostDraft {
+	return &PostDraft{
+		PostID:           post.ID,
+		BaseVersion:      post.Version,
+		Title:            post.Title,
+		Introduction:     post.Introduction,
+		Content:          post.Content,
+		CategoryID:       post.CategoryID,
+		ThumbnailImage:   post.ThumbnailImage,
+		ThumbnailMediaID: post.ThumbnailMediaID,
+		Tags:             post.Tags,
+	}
+}
+
+// Apply copies the content of the draft onto post
+func (d *PostDraft) Apply(post *Post) {
+	post.Title = d.Title
+	post.Introduction = d.Introduction
+	post.Content = d.Content
+	if d.CategoryID != 0 {
+		post.CategoryID = d.CategoryID
+	}
+	post.ThumbnailImage = d.ThumbnailImage
+	post.ThumbnailMediaID = d.ThumbnailMediaID
+	post.Tags = d.Tags
+}
+
+type PostDraftStore struct {
+	db *sql.DB
+}
+
+func (s *PostDraftStore) Get(ctx context.Context, postID int64) (*PostDraft, error) {
+	query := `
+		SELECT post_id, user_id, revision, base_version, title, introduction, content, COALESCE(category_id, 0),
+			thumbnail_image, thumbnail_media_id, tags, created_at, updated_at
+		FROM post_drafts
+		WHERE post_id = $1
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var draft PostDraft
+	err := s.db.QueryRowContext(ctx, query, postID).Scan(
+		&draft.PostID,
+		&draft.UserID,
+		&draft.Revision,
+		&draft.BaseVersion,
+		&draft.Title,
+		&draft.Introduction,
+		&draft.Content,
+		&draft.CategoryID,
+		&draft.ThumbnailImage,
+		&draft.ThumbnailMediaID,
+		pq.Array(&draft.Tags),
+		&draft.CreatedAt,
+		&draft.UpdatedAt,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &draft, nil
+}
+
+// Save writes the draft if it is still at draft.Revision, 0 meaning that
+// there is no draft yet, and moves it to the next revision. Otherwise it
+// returns ErrDraftConflict.
+func (s *PostDraftStore) Save(ctx context.Context, draft *PostDraft) error {
+	// the insert only happens for new drafts and the update only for the
+	// expected revision, anything else returns no row
+	query := `
+		INSERT INTO post_drafts (post_id, user_id, base_version, title, introduction, content, category_id,
+			thumbnail_image, thumbnail_media_id, tags)
+		SELECT $1, $2, $3, $4, $5, $6, NULLIF($7, 0), $8, $9, COALESCE($10::text[], '{}')
+		WHERE $11 = 0
+		ON CONFLICT (post_id) DO UPDATE
+		SET user_id = EXCLUDED.user_id, title = EXCLUDED.title, introduction = EXCLUDED.introduction,
+			content = EXCLUDED.content, category_id = EXCLUDED.category_id,
+			thumbnail_image = EXCLUDED.thumbnail_image, thumbnail_media_id = EXCLUDED.thumbnail_media_id,
+			tags = EXCLUDED.tags, revision = post_drafts.revision + 1, updated_at = NOW()
+		WHERE post_drafts.revision = $11
+		RETURNING revision, base_version, created_at, updated_at
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	err := s.db.QueryRowContext(
+		ctx,
+		query,
+		draft.PostID,
+		draft.UserID,
+		draft.BaseVersion,
+		draft.Title,
+		draft.Introduction,
+		draft.Content,
+		draft.CategoryID,
+		draft.ThumbnailImage,
+		draft.ThumbnailMediaID,
+		pq.Array(draft.Tags),
+		draft.Revision,
+	).Scan(&draft.Revision, &draft.BaseVersion, &draft.CreatedAt, &draft.UpdatedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrDraftConflict
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Publish updates post, which the draft at revision was applied to, and
+// removes the draft in the same transaction. It returns ErrNotFound when the
+// post changed since it was read and ErrDraftConflict when the draft did.
+func (s *PostDraftStore) Publish(ctx context.Context, post *Post, revision int) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return withTx(s.db, ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `DELETE FROM post_drafts WHERE post_id = $1 AND revision = $2`, post.ID, revision)
+		if err != nil {
+			return err
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return ErrDraftConflict
+		}
+
+		err = tx.QueryRowContext(ctx, updatePostQuery, updatePostArgs(post)...).Scan(&post.Version, &post.UpdatedAt, &post.PublishedAt)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return ErrNotFound
+			default:
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Delete discards the draft of a post
+func (s *PostDraftStore) Delete(ctx context.Context, postID int64) error {
+	query := `DELETE FROM post_drafts WHERE post_id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, postID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
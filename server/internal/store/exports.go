@@ -0,0 +1,94 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// ExportedPost carries what the Markdown export writes for a post
+type ExportedPost struct {
+	ID             int64
+	Title          string
+	Introduction   string
+	Content        string
+	Category       string
+	Status         string
+	Tags           []string
+	ImportKey      string
+	AuthorUsername string
+	CreatedAt      string
+}
+
+func (s *PostStore) GetIDByImportKey(ctx context.Context, userID int64, key string) (int64, error) {
+	query := `
+		SELECT id FROM posts
+		WHERE user_id = $1 AND import_key = $2 AND deleted_at IS NULL
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var id int64
+	err := s.db.QueryRowContext(ctx, query, userID, key).Scan(&id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return 0, ErrNotFound
+		default:
+			return 0, err
+		}
+	}
+
+	return id, nil
+}
+
+// GetForExport returns the posts of a user, or of every user when userID is
+// 0, oldest first. Trashed posts are left out.
+func (s *PostStore) GetForExport(ctx context.Context, userID int64) ([]ExportedPost, error) {
+	query := `
+		SELECT p.id, p.title, COALESCE(p.introduction, ''), p.content, COALESCE(c.name, ''), p.status, p.tags,
+			COALESCE(p.import_key, ''), u.username, p.created_at
+		FROM posts p
+		JOIN users u ON u.id = p.user_id
+		LEFT JOIN categories c ON c.id = p.category_id
+		WHERE p.deleted_at IS NULL AND ($1 = 0 OR p.user_id = $1)
+		ORDER BY p.created_at, p.id
+	`
+	ctx, cancel := context.WithTimeout(ctx, BatchQueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	posts := []ExportedPost{}
+	for rows.Next() {
+		var post ExportedPost
+		if err := rows.Scan(
+			&post.ID,
+			&post.Title,
+			&post.Introduction,
+			&post.Content,
+			&post.Category,
+			&post.Status,
+			pq.Array(&post.Tags),
+			&post.ImportKey,
+			&post.AuthorUsername,
+			&post.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
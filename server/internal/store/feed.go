@@ -0,0 +1,233 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const feedSelectQuery = `
+	SELECT p.id, p.title, p.introduction, p.category_id, c.name AS category, p.updated_at, p.thumbnail_image,
+	 p.user_id, u.name, u.profile_picture, p.status, ps.likes_count, ps.comments_count, ps.trending_score
+	FROM posts p
+	JOIN post_stats ps ON ps.post_id = p.id
+	LEFT JOIN users u ON u.id = p.user_id
+	LEFT JOIN categories c ON c.id = p.category_id
+`
+
+// feedItemFields returns the scan destinations of the columns selected by
+// feedSelectQuery, in order
+func feedItemFields(item *FeedItem) []any {
+	return []any{
+		&item.ID,
+		&item.Title,
+		&item.Introduction,
+		&item.CategoryID,
+		&item.Category,
+		&item.UpdatedAt,
+		&item.ThumbnailImage,
+		&item.UserID,
+		&item.AuthorName,
+		&item.AuthorProfilePicture,
+		&item.Status,
+		&item.LikesCount,
+		&item.CommentsCount,
+		&item.TrendingScore,
+	}
+}
+
+// FeedCursor is the decoded keyset position of a feed page. It is handed to
+// clients as an opaque signed string, see the cursor package.
+type FeedCursor struct {
+	Sort     string  `json:"s"`
+	Window   string  `json:"w,omitempty"`
+	Key      float64 `json:"k,omitempty"`
+	ID       int64   `json:"i"`
+	Backward bool    `json:"b,omitempty"`
+}
+
+// NewFeedCursor returns the cursor pointing at item for the ordering of fq
+func NewFeedCursor(fq PaginatedFeedQuery, item FeedItem, backward bool) FeedCursor {
+	cursor := FeedCursor{
+		Sort:     fq.Sort,
+		Window:   fq.Window,
+		ID:       item.ID,
+		Backward: backward,
+	}
+
+	switch fq.Sort {
+	case "trending":
+		cursor.Key = item.TrendingScore
+	case "top":
+		cursor.Key = float64(item.LikesCount)
+	case "most_commented":
+		cursor.Key = float64(item.CommentsCount)
+	}
+
+	return cursor
+}
+
+// feedOrder describes how a feed sort mode maps to SQL. Popularity sorts use
+// the columns of post_stats, which are indexed together with post_id so both
+// the ORDER BY and the keyset comparison stay index backed.
+type feedOrder struct {
+	column  string
+	keyType string
+	desc    bool
+}
+
+func getFeedOrder(fq *PaginatedFeedQuery) feedOrder {
+	switch fq.Sort {
+	case "trending":
+		return feedOrder{column: "ps.trending_score", keyType: "double precision", desc: true}
+	case "top":
+		return feedOrder{column: "ps.likes_count", keyType: "bigint", desc: true}
+	case "most_commented":
+		return feedOrder{column: "ps.comments_count", keyType: "bigint", desc: true}
+	case "asc":
+		return feedOrder{desc: false}
+	default:
+		return feedOrder{desc: true}
+	}
+}
+
+func (o feedOrder) orderBy(reverse bool) string {
+	direction := "DESC"
+	if o.desc == reverse {
+		direction = "ASC"
+	}
+
+	if o.column == "" {
+		return "p.id " + direction
+	}
+
+	return fmt.Sprintf("%s %s, ps.post_id %s", o.column, direction, direction)
+}
+
+func (o feedOrder) keyset(cursor *FeedCursor, queryParams []any) (string, []any) {
+	op := ">"
+	if o.desc != cursor.Backward {
+		op = "<"
+	}
+
+	if o.column == "" {
+		condition := fmt.Sprintf("p.id %s $%d", op, len(queryParams)+1)
+		return condition, append(queryParams, cursor.ID)
+	}
+
+	condition := fmt.Sprintf("(%s, ps.post_id) %s ($%d::%s, $%d)",
+		o.column, op, len(queryParams)+1, o.keyType, len(queryParams)+2)
+	return condition, append(queryParams, cursor.Key, cursor.ID)
+}
+
+func getFeedKeysetQuery(fq *PaginatedFeedQuery, cursor *FeedCursor) (string, []any) {
+	// one extra row tells us whether there is another page in the same direction
+	whereConditions, queryParams := getFeedFilters(fq, []any{
+		fq.Limit + 1,
+	})
+
+	order := getFeedOrder(fq)
+
+	if cursor != nil {
+		var condition string
+		condition, queryParams = order.keyset(cursor, queryParams)
+		whereConditions = append(whereConditions, condition)
+	}
+
+	if fq.SeparatePinned {
+		whereConditions = append(whereConditions, unpinnedCondition(fq))
+	}
+
+	finalQuery := feedSelectQuery
+	if len(whereConditions) > 0 {
+		finalQuery += " WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	backward := cursor != nil && cursor.Backward
+	finalQuery += " ORDER BY " + order.orderBy(backward) + " LIMIT $1"
+
+	return finalQuery, queryParams
+}
+
+// GetFeedByCursor returns the page of the feed that follows (or precedes, for
+// backward cursors) the given position, in display order. A nil cursor starts
+// from the beginning of the feed. hasMore reports whether another page exists
+// beyond the returned one in the direction of travel.
+func (s *PostStore) GetFeedByCursor(ctx context.Context, fq PaginatedFeedQuery, cursor *FeedCursor) ([]FeedItem, bool, error) {
+	query, queryParams := getFeedKeysetQuery(&fq, cursor)
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	feed := []FeedItem{}
+	for rows.Next() {
+		var item FeedItem
+		err := rows.Scan(feedItemFields(&item)...)
+		if err != nil {
+			return nil, false, err
+		}
+
+		feed = append(feed, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(feed) > fq.Limit
+	if hasMore {
+		feed = feed[:fq.Limit]
+	}
+
+	if cursor != nil && cursor.Backward {
+		for i, j := 0, len(feed)-1; i < j; i, j = i+1, j-1 {
+			feed[i], feed[j] = feed[j], feed[i]
+		}
+	}
+
+	return feed, hasMore, nil
+}
+
+func (s *PostStore) CountFeed(ctx context.Context, fq PaginatedFeedQuery) (int64, error) {
+	query, queryParams := getFeedCountQuery(&fq)
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var total int64
+	if err := s.db.QueryRowContext(ctx, query, queryParams...).Scan(&total); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// RefreshTrendingScores re-applies the time decay to the trending score of
+// every recent post. Likes and comments update the score of their post right
+// away, this only keeps the ranking moving while a post gets no activity.
+func (s *PostStore) RefreshTrendingScores(ctx context.Context) (int64, error) {
+	query := `
+		UPDATE post_stats ps
+		SET trending_score = post_trending_score(ps.likes_count, ps.comments_count, p.created_at),
+			score_updated_at = NOW()
+		FROM posts p
+		WHERE p.id = ps.post_id
+			AND (p.created_at >= NOW() - INTERVAL '30 days' OR ps.trending_score <> 0)
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, BatchQueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
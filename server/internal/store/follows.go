@@ -0,0 +1,155 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+)
+
+type FollowUser struct {
+	ID             int64  `json:"id"`
+	Name           string `json:"name"`
+	Username       string `json:"username"`
+	Bio            string `json:"bio"`
+	ProfilePicture string `json:"profile_picture"`
+	FollowedAt     string `json:"followed_at"`
+}
+
+type FollowCounts struct {
+	Followers int64 `json:"followers"`
+	Following int64 `json:"following"`
+}
+
+type FollowStore struct {
+	db *sql.DB
+}
+
+func (s *FollowStore) Follow(ctx context.Context, followerID, followeeID int64) error {
+	query := `
+		INSERT INTO user_follows (follower_id, followee_id)
+		VALUES ($1, $2)
+		ON CONFLICT (follower_id, followee_id) DO NOTHING
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := s.db.ExecContext(ctx, query, followerID, followeeID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *FollowStore) Unfollow(ctx context.Context, followerID, followeeID int64) error {
+	query := `
+		DELETE FROM user_follows
+		WHERE follower_id = $1 AND followee_id = $2
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, followerID, followeeID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *FollowStore) IsFollowing(ctx context.Context, followerID, followeeID int64) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM user_follows
+			WHERE follower_id = $1 AND followee_id = $2
+		)
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var exists bool
+	err := s.db.QueryRowContext(ctx, query, followerID, followeeID).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func (s *FollowStore) GetCounts(ctx context.Context, userID int64) (*FollowCounts, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM user_follows WHERE followee_id = $1) AS followers,
+			(SELECT COUNT(*) FROM user_follows WHERE follower_id = $1) AS following
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var counts FollowCounts
+	err := s.db.QueryRowContext(ctx, query, userID).Scan(&counts.Followers, &counts.Following)
+	if err != nil {
+		return nil, err
+	}
+
+	return &counts, nil
+}
+
+// GetFollowers returns the users following userID, most recent first
+func (s *FollowStore) GetFollowers(ctx context.Context, userID int64, page, limit int) ([]FollowUser, int64, error) {
+	return s.list(ctx, "followee_id", "follower_id", userID, page, limit)
+}
+
+// GetFollowing returns the users userID follows, most recent first
+func (s *FollowStore) GetFollowing(ctx context.Context, userID int64, page, limit int) ([]FollowUser, int64, error) {
+	return s.list(ctx, "follower_id", "followee_id", userID, page, limit)
+}
+
+func (s *FollowStore) list(ctx context.Context, matchColumn, userColumn string, userID int64, page, limit int) ([]FollowUser, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM user_follows WHERE ` + matchColumn + ` = $1`
+	if err := s.db.QueryRowContext(ctx, countQuery, userID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT u.id, u.name, u.username, COALESCE(u.bio, ''), COALESCE(u.profile_picture, ''), uf.created_at
+		FROM user_follows uf
+		JOIN users u ON u.id = uf.` + userColumn + `
+		WHERE uf.` + matchColumn + ` = $1
+		ORDER BY uf.created_at DESC, u.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, userID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	users := []FollowUser{}
+	for rows.Next() {
+		var u FollowUser
+		if err := rows.Scan(&u.ID, &u.Name, &u.Username, &u.Bio, &u.ProfilePicture, &u.FollowedAt); err != nil {
+			return nil, 0, err
+		}
+
+		users = append(users, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
@@ -93,3 +93,58 @@ func (s *PostLikeStore) HasUserLiked(ctx context.Context, postID, userID int64)
 
 	return exists, nil
 }
+
+type CommentLikeStore struct {
+	db *sql.DB
+}
+
+// LikeComment likes a comment, liking it twice is a no-op. It reports
+// whether the like is new.
+func (s *CommentLikeStore) LikeComment(ctx context.Context, commentID, userID int64) (bool, error) {
+	query := `
+		INSERT INTO comment_likes (comment_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (comment_id, user_id) DO NOTHING
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, commentID, userID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// UnlikeComment removes a like from a comment, it returns ErrNotFound when
+// the user had not liked it
+func (s *CommentLikeStore) UnlikeComment(ctx context.Context, commentID, userID int64) error {
+	query := `
+		DELETE FROM comment_likes
+		WHERE comment_id = $1 AND user_id = $2
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, commentID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+)
+
+type MediaVariant struct {
+	Name        string `json:"name"`
+	Key         string `json:"key"`
+	ContentType string `json:"content_type"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	SizeBytes   int64  `json:"size_bytes"`
+	URL         string `json:"url,omitempty"`
+}
+
+type Media struct {
+	ID          int64          `json:"id"`
+	UserID      int64          `json:"user_id"`
+	ContentType string         `json:"content_type"`
+	Width       int            `json:"width"`
+	Height      int            `json:"height"`
+	SizeBytes   int64          `json:"size_bytes"`
+	Variants    []MediaVariant `json:"variants"`
+	CreatedAt   string         `json:"created_at"`
+}
+
+// Variant returns the rendition called name, or nil if there is none
+func (m *Media) Variant(name string) *MediaVariant {
+	for i := range m.Variants {
+		if m.Variants[i].Name == name {
+			return &m.Variants[i]
+		}
+	}
+
+	return nil
+}
+
+type MediaStore struct {
+	db *sql.DB
+}
+
+func (s *MediaStore) Create(ctx context.Context, media *Media) error {
+	query := `
+		INSERT INTO media (user_id, content_type, width, height, size_bytes, variants)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+
+	variants, err := json.Marshal(media.Variants)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return s.db.QueryRowContext(
+		ctx,
+		query,
+		media.UserID,
+		media.ContentType,
+		media.Width,
+		media.Height,
+		media.SizeBytes,
+		variants,
+	).Scan(
+		&media.ID,
+		&media.CreatedAt,
+	)
+}
+
+func (s *MediaStore) GetByID(ctx context.Context, id int64) (*Media, error) {
+	query := `
+		SELECT id, COALESCE(user_id, 0), content_type, width, height, size_bytes, variants, created_at
+		FROM media
+		WHERE id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var media Media
+	var variants []byte
+	err := s.db.QueryRowContext(ctx, query, id).Scan(
+		&media.ID,
+		&media.UserID,
+		&media.ContentType,
+		&media.Width,
+		&media.Height,
+		&media.SizeBytes,
+		&variants,
+		&media.CreatedAt,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if err := json.Unmarshal(variants, &media.Variants); err != nil {
+		return nil, err
+	}
+
+	return &media, nil
+}
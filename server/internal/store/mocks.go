@@ -38,6 +38,10 @@ func (m *MockUserStore) GetByEmail(context.Context, string) (*User, error) {
 	return nil, nil
 }
 
+func (m *MockUserStore) GetByUsername(context.Context, string) (*User, error) {
+	return nil, nil
+}
+
 func (m *MockUserStore) GetByID(ctx context.Context, id int64) (*User, error) {
 	return nil, nil
 }
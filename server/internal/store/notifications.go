@@ -9,7 +9,7 @@ import (
 type Notification struct {
 	ID             int64  `json:"id"`
 	UserID         int64  `json:"user_id"`
-	Type           string `json:"type"` // "like", "comment", etc.
+	Type           string `json:"type"` // "like", "comment", "new_post", etc.
 	RelatedID      int64  `json:"related_id"`
 	ActorID        int64  `json:"actor_id"`
 	Message        string `json:"message"`
@@ -55,6 +55,50 @@ func (s *NotificationStore) Create(ctx context.Context, notification *Notificati
 	return nil
 }
 
+// CreateForFollowers fans a notification about an action of ActorID out to
+// everyone following them, and returns the IDs of the notified users
+func (s *NotificationStore) CreateForFollowers(ctx context.Context, notification *Notification) ([]int64, error) {
+	query := `
+		INSERT INTO notifications
+		(user_id, type, related_id, actor_id, message, is_read)
+		SELECT uf.follower_id, $1, $2, $3, $4, false
+		FROM user_follows uf
+		WHERE uf.followee_id = $3
+		RETURNING user_id
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		query,
+		notification.Type,
+		notification.RelatedID,
+		notification.ActorID,
+		notification.Message,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := []int64{}
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
+
 func (s *NotificationStore) Get(ctx context.Context, userID int64, page, limit int) ([]Notification, int64, error) {
 	// Get total count
 	var total int64
@@ -71,17 +115,17 @@ func (s *NotificationStore) Get(ctx context.Context, userID int64, page, limit i
 			n.id, n.user_id, n.type, n.related_id, n.actor_id, n.message, n.is_read, n.created_at,
 			u.name as actor_name, u.profile_picture as actor_profile_picture,
 			CASE
-				WHEN n.type = 'comment' THEN c.post_id
+				WHEN n.type IN ('comment', 'reply', 'comment_like') THEN c.post_id
 				ELSE n.related_id
 			END as post_id,
 			p.title as post_title,
 			c.content as comment_content
 		FROM notifications n
 		LEFT JOIN users u ON n.actor_id = u.id
-		LEFT JOIN comments c ON n.type = 'comment' AND n.related_id = c.id
+		LEFT JOIN comments c ON n.type IN ('comment', 'reply', 'comment_like') AND n.related_id = c.id
 		LEFT JOIN posts p ON
 			CASE
-				WHEN n.type = 'comment' THEN p.id = c.post_id
+				WHEN n.type IN ('comment', 'reply', 'comment_like') THEN p.id = c.post_id
 				ELSE p.id = n.related_id
 			END
 		WHERE n.user_id = $1
@@ -142,8 +186,20 @@ func (s *NotificationStore) Get(ctx context.Context, userID int64, page, limit i
 			if commentContent.Valid {
 				notification.CommentContent = commentContent.String
 			}
+		case "reply":
+			action = "replied to your comment on:"
+			if commentContent.Valid {
+				notification.CommentContent = commentContent.String
+			}
+		case "comment_like":
+			action = "liked your comment on:"
+			if commentContent.Valid {
+				notification.CommentContent = commentContent.String
+			}
 		case "like":
 			action = "liked your article:"
+		case "new_post":
+			action = "published a new article:"
 		default:
 			action = "interacted with your article:"
 		}
@@ -178,12 +234,12 @@ func (s *NotificationStore) GetByUserID(ctx context.Context, userID int64, limit
 			n.id, n.user_id, n.type, n.related_id, n.actor_id, n.message, n.is_read, n.created_at,
 			u.name as actor_name, u.profile_picture as actor_profile_picture,
 			CASE
-				WHEN n.type = 'comment' THEN c.post_id
+				WHEN n.type IN ('comment', 'reply', 'comment_like') THEN c.post_id
 				ELSE n.related_id
 			END as post_id
 		FROM notifications n
 		LEFT JOIN users u ON n.actor_id = u.id
-		LEFT JOIN comments c ON n.type = 'comment' AND n.related_id = c.id
+		LEFT JOIN comments c ON n.type IN ('comment', 'reply', 'comment_like') AND n.related_id = c.id
 		WHERE n.user_id = $1
 		ORDER BY n.created_at DESC
 		LIMIT $2 OFFSET $3
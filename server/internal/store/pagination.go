@@ -1,17 +1,52 @@
 package store
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 )
 
+// feedDateLayout is the layout of the dates accepted by the from and to
+// params, RFC 3339 timestamps are accepted too
+const feedDateLayout = "2006-01-02"
+
+var errFeedDateRange = errors.New("from must not be after to")
+
 type PaginatedFeedQuery struct {
 	Limit    int    `json:"limit" validate:"gte=1,lte=20"`
 	Page     int    `json:"page" validate:"gte=1"`
-	Sort     string `json:"sort" validate:"oneof=asc desc"`
+	Sort     string `json:"sort" validate:"oneof=asc desc trending top most_commented"`
+	Window   string `json:"window" validate:"omitempty,oneof=24h 7d 30d 365d all"`
 	Category string `json:"category" validat:"omitempty"`
 	Search   string `json:"search" validate:"omitempty,max=100"`
-	Status   string `json:"status" validate:"omitempty,oneof=published draft"`
+	// Status matches the post statuses, see PostStatusPublished, ignoring case
+	Status string `json:"status" validate:"omitempty,oneof=published draft submitted 'in review' approved 'changes requested'"`
+	// Author is the username of the author of the posts
+	Author string `json:"author" validate:"omitempty,max=255"`
+	// From and To limit the feed to the posts created in between, both
+	// included. Dates cover the whole day in UTC.
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	// Locale limits the feed to the posts written in or translated to a
+	// language, it is canonicalized by the caller
+	Locale string `json:"lang" validate:"omitempty,max=35"`
+	// Cursor switches the feed to keyset pagination. UseCursor is set when the
+	// cursor param is present, so an empty cursor requests the first page.
+	Cursor       string `json:"cursor" validate:"omitempty,max=512"`
+	UseCursor    bool   `json:"-"`
+	IncludeTotal bool   `json:"include_total"`
+	// SeparatePinned leaves the pinned posts out of the feed, the caller lists
+	// them first with GetPinned
+	SeparatePinned bool `json:"-"`
+	// FollowerID limits the feed to authors followed by this user
+	FollowerID int64 `json:"-"`
+	// ViewerID and ViewerCanSeeDrafts decide which drafts are visible. Others
+	// only ever see published posts, authors also see their own drafts.
+	ViewerID           int64 `json:"-"`
+	ViewerCanSeeDrafts bool  `json:"-"`
 }
 
 // Parse extracts pagination parameters from the request query string
@@ -54,10 +89,134 @@ func (fq PaginatedFeedQuery) Parse(r *http.Request) (PaginatedFeedQuery, error)
 		fq.Status = status
 	}
 
+	if window := queryString.Get("window"); window != "" {
+		fq.Window = window
+	}
+
+	if author := queryString.Get("author"); author != "" {
+		fq.Author = author
+	}
+
+	if lang := queryString.Get("lang"); lang != "" {
+		fq.Locale = lang
+	}
+
+	if from := queryString.Get("from"); from != "" {
+		t, err := parseFeedDate(from, false)
+		if err != nil {
+			return fq, fmt.Errorf("invalid from: %w", err)
+		}
+		fq.From = t
+	}
+
+	if to := queryString.Get("to"); to != "" {
+		t, err := parseFeedDate(to, true)
+		if err != nil {
+			return fq, fmt.Errorf("invalid to: %w", err)
+		}
+		fq.To = t
+	}
+
+	if !fq.From.IsZero() && !fq.To.IsZero() && fq.From.After(fq.To) {
+		return fq, errFeedDateRange
+	}
+
+	if queryString.Has("cursor") {
+		fq.UseCursor = true
+		fq.Cursor = queryString.Get("cursor")
+	}
+
+	if includeTotal := queryString.Get("include_total"); includeTotal != "" {
+		fq.IncludeTotal, _ = strconv.ParseBool(includeTotal)
+	}
+
 	return fq, nil
 }
 
+// parseFeedDate parses a date or a timestamp. Dates used as the end of a
+// range stand for the last instant of the day.
+func parseFeedDate(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(feedDateLayout, value); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1).Add(-time.Microsecond)
+		}
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected a YYYY-MM-DD date or an RFC 3339 timestamp")
+	}
+
+	return t, nil
+}
+
+// ShowsPinned reports whether the pinned posts are listed first. They are on
+// the newest first feed, not on searches, popularity sorts or followed authors.
+func (fq PaginatedFeedQuery) ShowsPinned() bool {
+	return fq.Sort == "desc" && fq.Search == "" && fq.FollowerID == 0
+}
+
 // GetOffset calculates the offset for SQL LIMIT/OFFSET pagination from the page number
 func (fq PaginatedFeedQuery) GetOffset() int {
 	return (fq.Page - 1) * fq.Limit
 }
+
+// GetWindowInterval returns the Postgres interval of the time window for the
+// top sort, or an empty string when the feed is not limited in time
+func (fq PaginatedFeedQuery) GetWindowInterval() string {
+	if fq.Sort != "top" {
+		return ""
+	}
+
+	switch fq.Window {
+	case "24h":
+		return "24 hours"
+	case "7d":
+		return "7 days"
+	case "30d":
+		return "30 days"
+	case "365d":
+		return "365 days"
+	default:
+		return ""
+	}
+}
+
+type PaginatedSearchQuery struct {
+	Limit    int    `json:"limit" validate:"gte=1,lte=20"`
+	Page     int    `json:"page" validate:"gte=1"`
+	Query    string `json:"q" validate:"required,max=200"`
+	Category string `json:"category" validate:"omitempty"`
+	Language string `json:"-"`
+}
+
+// Parse extracts search and pagination parameters from the request query string
+func (sq PaginatedSearchQuery) Parse(r *http.Request) (PaginatedSearchQuery, error) {
+	queryString := r.URL.Query()
+
+	if limit := queryString.Get("limit"); limit != "" {
+		if value, err := strconv.Atoi(limit); err == nil {
+			sq.Limit = value
+		}
+	}
+
+	if page := queryString.Get("page"); page != "" {
+		if value, err := strconv.Atoi(page); err == nil {
+			sq.Page = value
+		}
+	}
+
+	sq.Query = strings.TrimSpace(queryString.Get("q"))
+
+	if category := queryString.Get("category"); category != "" {
+		sq.Category = category
+	}
+
+	return sq, nil
+}
+
+// GetOffset calculates the offset for SQL LIMIT/OFFSET pagination from the page number
+func (sq PaginatedSearchQuery) GetOffset() int {
+	return (sq.Page - 1) * sq.Limit
+}
@@ -0,0 +1,200 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+const (
+	// PinScopeGlobal pins a post on the main feed and on the feed of its
+	// category, PinScopeCategory only on the latter
+	PinScopeGlobal   = "global"
+	PinScopeCategory = "category"
+)
+
+// activePinCondition matches the pins aliased pp that have not expired and
+// apply to the feed, the main feed only lists global pins
+func activePinCondition(fq *PaginatedFeedQuery) string {
+	condition := "(pp.expires_at IS NULL OR pp.expires_at > NOW())"
+	if fq.Category == "" {
+		condition += " AND pp.scope = 'global'"
+	}
+
+	return condition
+}
+
+// unpinnedCondition leaves out of a feed the posts GetPinned lists first
+func unpinnedCondition(fq *PaginatedFeedQuery) string {
+	return "NOT EXISTS(SELECT 1 FROM pinned_posts pp WHERE pp.post_id = p.id AND " + activePinCondition(fq) + ")"
+}
+
+// PostPin lists a post before the others on the first page of the feed
+type PostPin struct {
+	PostID int64  `json:"post_id"`
+	Scope  string `json:"scope"`
+	// Position orders the pinned posts, lowest first, ties are broken by the
+	// most recent pin
+	Position  int        `json:"position"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	// UserID is the moderator who pinned the post
+	UserID    *int64 `json:"user_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+// PostFeature lists a post in the homepage carousel
+type PostFeature struct {
+	PostID    int64      `json:"post_id"`
+	Position  int        `json:"position"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	// UserID is the moderator who featured the post
+	UserID    *int64 `json:"user_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+type FeaturedPost struct {
+	FeedItem
+	Position  int     `json:"position"`
+	ExpiresAt *string `json:"expires_at"`
+}
+
+type PinStore struct {
+	db *sql.DB
+}
+
+// Pin pins a post, or replaces the scope, position and expiry of its pin
+func (s *PinStore) Pin(ctx context.Context, pin *PostPin) error {
+	query := `
+		INSERT INTO pinned_posts (post_id, scope, position, expires_at, user_id)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (post_id) DO UPDATE
+		SET scope = EXCLUDED.scope, position = EXCLUDED.position, expires_at = EXCLUDED.expires_at,
+			user_id = EXCLUDED.user_id, created_at = NOW()
+		RETURNING created_at
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return s.db.QueryRowContext(ctx, query, pin.PostID, pin.Scope, pin.Position, pin.ExpiresAt, pin.UserID).
+		Scan(&pin.CreatedAt)
+}
+
+func (s *PinStore) Unpin(ctx context.Context, postID int64) error {
+	return s.delete(ctx, `DELETE FROM pinned_posts WHERE post_id = $1`, postID)
+}
+
+// Feature features a post, or replaces the position and expiry it is
+// featured with
+func (s *PinStore) Feature(ctx context.Context, feature *PostFeature) error {
+	query := `
+		INSERT INTO featured_posts (post_id, position, expires_at, user_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (post_id) DO UPDATE
+		SET position = EXCLUDED.position, expires_at = EXCLUDED.expires_at, user_id = EXCLUDED.user_id,
+			created_at = NOW()
+		RETURNING created_at
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return s.db.QueryRowContext(ctx, query, feature.PostID, feature.Position, feature.ExpiresAt, feature.UserID).
+		Scan(&feature.CreatedAt)
+}
+
+func (s *PinStore) Unfeature(ctx context.Context, postID int64) error {
+	return s.delete(ctx, `DELETE FROM featured_posts WHERE post_id = $1`, postID)
+}
+
+func (s *PinStore) delete(ctx context.Context, query string, postID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, postID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetFeatured returns the published posts that are featured and have not
+// expired, in carousel order
+func (s *PinStore) GetFeatured(ctx context.Context, limit int) ([]FeaturedPost, error) {
+	query := `
+		SELECT p.id, p.title, p.introduction, p.category_id, c.name AS category, p.updated_at, p.thumbnail_image,
+			p.user_id, u.name, u.profile_picture, p.status, ps.likes_count, ps.comments_count, ps.trending_score,
+			fp.position, fp.expires_at
+		FROM featured_posts fp
+		JOIN posts p ON p.id = fp.post_id
+		JOIN post_stats ps ON ps.post_id = p.id
+		LEFT JOIN users u ON u.id = p.user_id
+		LEFT JOIN categories c ON c.id = p.category_id
+		WHERE p.deleted_at IS NULL AND p.status ILIKE 'published'
+			AND (fp.expires_at IS NULL OR fp.expires_at > NOW())
+		ORDER BY fp.position, fp.created_at DESC
+		LIMIT $1
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	posts := []FeaturedPost{}
+	for rows.Next() {
+		var post FeaturedPost
+		fields := append(feedItemFields(&post.FeedItem), &post.Position, &post.ExpiresAt)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+
+		posts = append(posts, post)
+	}
+
+	return posts, rows.Err()
+}
+
+// GetPinned returns the posts pinned on the feed described by fq that match
+// its filters, in pin order
+func (s *PostStore) GetPinned(ctx context.Context, fq PaginatedFeedQuery) ([]FeedItem, error) {
+	whereConditions, queryParams := getFeedFilters(&fq, nil)
+	whereConditions = append(whereConditions, activePinCondition(&fq))
+
+	query := feedSelectQuery + " JOIN pinned_posts pp ON pp.post_id = p.id" +
+		" WHERE " + strings.Join(whereConditions, " AND ") +
+		" ORDER BY pp.position, pp.created_at DESC"
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pinned := []FeedItem{}
+	for rows.Next() {
+		item := FeedItem{Pinned: true}
+		if err := rows.Scan(feedItemFields(&item)...); err != nil {
+			return nil, err
+		}
+
+		pinned = append(pinned, item)
+	}
+
+	return pinned, rows.Err()
+}
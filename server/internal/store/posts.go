@@ -6,65 +6,109 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/lib/pq"
+)
+
+const (
+	PostStatusPublished = "Published"
+	PostStatusDraft     = "Draft"
+	// review workflow, see CheckStatusTransition
+	PostStatusSubmitted        = "Submitted"
+	PostStatusInReview         = "In Review"
+	PostStatusApproved         = "Approved"
+	PostStatusChangesRequested = "Changes requested"
 )
 
 type Author struct {
+	ID             int64  `json:"id"`
 	Name           string `json:"name"`
 	Bio            string `json:"bio"`
 	ProfilePicture string `json:"profile_picture"`
+	// Permission is only set on co-authors
+	Permission string `json:"permission,omitempty"`
 }
 
 type Post struct {
-	ID             int64     `json:"id"`
-	Title          string    `json:"title"`
-	Introduction   string    `json:"introduction"`
-	Content        string    `json:"content"`
-	CategoryID     int64     `json:"category_id"`
-	UserID         int64     `json:"user_id"`
-	ThumbnailImage string    `json:"thumbnail_image"`
-	CreatedAt      string    `json:"created_at"`
-	UpdatedAt      string    `json:"updated_at"`
-	Version        int       `json:"version"`
-	Comments       []Comment `json:"comments"`
-	Author         *Author   `json:"author"`
-	Category       string    `json:"category"`
-	LikesCount     int64     `json:"likes_count"`
-	UserHasLiked   bool      `json:"user_has_liked"`
-	Status         string    `json:"status"`
+	ID             int64  `json:"id"`
+	Title          string `json:"title"`
+	Introduction   string `json:"introduction"`
+	Content        string `json:"content"`
+	CategoryID     int64  `json:"category_id"`
+	UserID         int64  `json:"user_id"`
+	ThumbnailImage string `json:"thumbnail_image"`
+	// ThumbnailMediaID references the uploaded media ThumbnailImage points to
+	ThumbnailMediaID *int64 `json:"thumbnail_media_id"`
+	CreatedAt        string `json:"created_at"`
+	UpdatedAt        string `json:"updated_at"`
+	// PublishedAt is when the post was first published, it is kept when the
+	// post is unpublished
+	PublishedAt *string   `json:"published_at"`
+	Version     int       `json:"version"`
+	Comments    []Comment `json:"comments"`
+	// CommentsNextCursor continues Comments when only their first page was
+	// embedded
+	CommentsNextCursor string            `json:"comments_next_cursor,omitempty"`
+	Series             *SeriesNavigation `json:"series,omitempty"`
+	Author             *Author           `json:"author"`
+	CoAuthors          []Author          `json:"co_authors"`
+	Category           string            `json:"category"`
+	LikesCount         int64             `json:"likes_count"`
+	CommentsCount      int64             `json:"comments_count"`
+	UserHasLiked       bool              `json:"user_has_liked"`
+	UserHasBookmarked  bool              `json:"user_has_bookmarked"`
+	Status             string            `json:"status"`
+	Tags               []string          `json:"tags"`
+	// Locale is the language the post is written in, or the locale of the
+	// translation it was localized to, see Localize
+	Locale string `json:"locale"`
+	// Slug is only set when the post was localized to a translation
+	Slug string `json:"slug,omitempty"`
+	// Alternates lists every language the post can be read in
+	Alternates     []Alternate `json:"alternates,omitempty"`
+	SearchLanguage string      `json:"-"`
+	// ImportKey is set on posts imported from Markdown, see the markdown package
+	ImportKey string `json:"-"`
 }
 
 type FeedItem struct {
-	ID                   int64  `json:"id"`
-	Title                string `json:"title"`
-	Introduction         string `json:"introduction"`
-	CategoryID           int64  `json:"category_id"`
-	Category             string `json:"category"`
-	UpdatedAt            string `json:"updated_at"`
-	ThumbnailImage       string `json:"thumbnail_image"`
-	UserID               int64  `json:"user_id"`
-	AuthorName           string `json:"author_name"`
-	AuthorProfilePicture string `json:"author_profile_picture"`
-	Status               string `json:"status"`
+	ID                   int64   `json:"id"`
+	Title                string  `json:"title"`
+	Introduction         string  `json:"introduction"`
+	CategoryID           int64   `json:"category_id"`
+	Category             string  `json:"category"`
+	UpdatedAt            string  `json:"updated_at"`
+	ThumbnailImage       string  `json:"thumbnail_image"`
+	UserID               int64   `json:"user_id"`
+	AuthorName           string  `json:"author_name"`
+	AuthorProfilePicture string  `json:"author_profile_picture"`
+	Status               string  `json:"status"`
+	LikesCount           int64   `json:"likes_count"`
+	CommentsCount        int64   `json:"comments_count"`
+	TrendingScore        float64 `json:"trending_score"`
+	// Locale is only set on feeds filtered by language
+	Locale string `json:"locale,omitempty"`
+	// Pinned is set on the pinned posts listed first by the feed
+	Pinned bool `json:"pinned,omitempty"`
+}
+
+type SearchResult struct {
+	FeedItem
+	Rank float64 `json:"rank"`
+	// TitleHighlight and Snippet are HTML: the text of the post is escaped
+	// and the matches are wrapped in <mark> tags
+	TitleHighlight string `json:"title_highlight"`
+	Snippet        string `json:"snippet"`
 }
 
 type PostStore struct {
 	db *sql.DB
 }
 
-func getFeedQuery(fq *PaginatedFeedQuery, sort string) (string, []any) {
-	baseQuery := `
-		SELECT p.id, p.title, p.introduction, p.category_id, c.name AS category, p.updated_at, p.thumbnail_image,
-		 p.user_id, u.name, u.profile_picture, p.status
-		FROM posts p
-		LEFT JOIN users u ON u.id = p.user_id
-		LEFT JOIN categories c ON c.id = p.category_id
-	`
-
-	whereConditions := []string{}
-	var queryParams = []any{
-		fq.Limit,
-		fq.GetOffset(),
-	}
+// getFeedFilters builds the WHERE conditions shared by every feed query.
+// Placeholders are numbered after the params that are already in queryParams.
+func getFeedFilters(fq *PaginatedFeedQuery, queryParams []any) ([]string, []any) {
+	whereConditions := []string{"p.deleted_at IS NULL"}
 
 	if fq.Search != "" {
 		whereConditions = append(whereConditions, fmt.Sprintf("(p.title ILIKE '%%' || $%d || '%%' OR p.introduction ILIKE '%%' || $%d || '%%')",
@@ -84,12 +128,73 @@ func getFeedQuery(fq *PaginatedFeedQuery, sort string) (string, []any) {
 		queryParams = append(queryParams, fq.Status)
 	}
 
-	finalQuery := baseQuery
+	if fq.Author != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("u.username = $%d", len(queryParams)+1))
+		queryParams = append(queryParams, fq.Author)
+	}
+
+	if !fq.From.IsZero() {
+		whereConditions = append(whereConditions, fmt.Sprintf("p.created_at >= $%d", len(queryParams)+1))
+		queryParams = append(queryParams, fq.From)
+	}
+
+	if !fq.To.IsZero() {
+		whereConditions = append(whereConditions, fmt.Sprintf("p.created_at <= $%d", len(queryParams)+1))
+		queryParams = append(queryParams, fq.To)
+	}
+
+	if fq.Locale != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf(
+			"(p.locale = $%[1]d OR EXISTS(SELECT 1 FROM post_translations pt WHERE pt.post_id = p.id AND pt.locale = $%[1]d))",
+			len(queryParams)+1,
+		))
+		queryParams = append(queryParams, fq.Locale)
+	}
+
+	if !fq.ViewerCanSeeDrafts {
+		if fq.ViewerID > 0 {
+			whereConditions = append(whereConditions, fmt.Sprintf(
+				"(p.status ILIKE 'published' OR p.user_id = $%[1]d OR EXISTS(SELECT 1 FROM post_coauthors pc WHERE pc.post_id = p.id AND pc.user_id = $%[1]d AND pc.accepted_at IS NOT NULL))",
+				len(queryParams)+1,
+			))
+			queryParams = append(queryParams, fq.ViewerID)
+		} else {
+			whereConditions = append(whereConditions, "p.status ILIKE 'published'")
+		}
+	}
+
+	if fq.FollowerID > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf(
+			"p.user_id IN (SELECT uf.followee_id FROM user_follows uf WHERE uf.follower_id = $%d)",
+			len(queryParams)+1,
+		))
+		queryParams = append(queryParams, fq.FollowerID)
+	}
+
+	if interval := fq.GetWindowInterval(); interval != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("p.created_at >= NOW() - $%d::interval", len(queryParams)+1))
+		queryParams = append(queryParams, interval)
+	}
+
+	return whereConditions, queryParams
+}
+
+func getFeedQuery(fq *PaginatedFeedQuery) (string, []any) {
+	whereConditions, queryParams := getFeedFilters(fq, []any{
+		fq.Limit,
+		fq.GetOffset(),
+	})
+
+	finalQuery := feedSelectQuery
+	if fq.SeparatePinned {
+		whereConditions = append(whereConditions, unpinnedCondition(fq))
+	}
+
 	if len(whereConditions) > 0 {
 		finalQuery += " WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
-	finalQuery += " ORDER BY p.id " + sort + " LIMIT $1 OFFSET $2"
+	finalQuery += " ORDER BY " + getFeedOrder(fq).orderBy(false) + " LIMIT $1 OFFSET $2"
 
 	return finalQuery, queryParams
 }
@@ -103,12 +208,7 @@ func (s *PostStore) GetFeed(ctx context.Context, fq PaginatedFeedQuery) ([]FeedI
 		return nil, 0, err
 	}
 
-	sort := strings.ToUpper(fq.Sort)
-	if sort != "ASC" && sort != "DESC" {
-		sort = "DESC"
-	}
-
-	query, queryParams := getFeedQuery(&fq, sort)
+	query, queryParams := getFeedQuery(&fq)
 
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
 	defer cancel()
@@ -126,19 +226,7 @@ func (s *PostStore) GetFeed(ctx context.Context, fq PaginatedFeedQuery) ([]FeedI
 	var feed []FeedItem
 	for rows.Next() {
 		var item FeedItem
-		err := rows.Scan(
-			&item.ID,
-			&item.Title,
-			&item.Introduction,
-			&item.CategoryID,
-			&item.Category,
-			&item.UpdatedAt,
-			&item.ThumbnailImage,
-			&item.UserID,
-			&item.AuthorName,
-			&item.AuthorProfilePicture,
-			&item.Status,
-		)
+		err := rows.Scan(feedItemFields(&item)...)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -152,8 +240,13 @@ func (s *PostStore) GetFeed(ctx context.Context, fq PaginatedFeedQuery) ([]FeedI
 func (s *PostStore) Create(ctx context.Context, post *Post) error {
 	query := `
 		INSERT INTO posts 
-		(title, introduction, content, category_id, user_id, thumbnail_image, status)
-		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, created_at, updated_at
+		(title, introduction, content, category_id, user_id, thumbnail_image, status, search_language, thumbnail_media_id,
+			tags, import_key, created_at, updated_at, locale)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE(NULLIF($8, ''), 'english')::regconfig, $9,
+			COALESCE($10::text[], '{}'), NULLIF($11, ''),
+			COALESCE(NULLIF($12, '')::timestamptz, NOW()), COALESCE(NULLIF($12, '')::timestamptz, NOW()),
+			COALESCE(NULLIF($13, ''), 'en'))
+		RETURNING id, created_at, updated_at, locale, published_at
 	`
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
 	defer cancel()
@@ -168,13 +261,26 @@ func (s *PostStore) Create(ctx context.Context, post *Post) error {
 		post.UserID,
 		post.ThumbnailImage,
 		post.Status,
+		post.SearchLanguage,
+		post.ThumbnailMediaID,
+		pq.Array(post.Tags),
+		post.ImportKey,
+		post.CreatedAt,
+		post.Locale,
 	).Scan(
 		&post.ID,
 		&post.CreatedAt,
 		&post.UpdatedAt,
+		&post.Locale,
+		&post.PublishedAt,
 	)
 
 	if err != nil {
+		// the only unique key of posts is their import key
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return ErrUniqueViolation
+		}
 		return err
 	}
 
@@ -187,28 +293,32 @@ func (s *PostStore) GetByID(ctx context.Context, id int64, currentUserID int64)
 
 	if currentUserID > 0 {
 		query = `
-			SELECT p.id, p.title, p.introduction, p.content, p.category_id, p.status,
-				p.user_id, p.thumbnail_image, p.created_at, p.updated_at, p.version,
-				u.name, u.bio, u.profile_picture, c.name as category,
+			SELECT p.id, p.title, p.introduction, p.content, p.category_id, p.status, p.tags, p.locale,
+				p.user_id, p.thumbnail_image, p.thumbnail_media_id, p.created_at, p.updated_at, p.version,
+				p.published_at, u.name, u.bio, u.profile_picture, c.name as category,
 				(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id) AS likes_count,
-				EXISTS(SELECT 1 FROM post_likes pl WHERE pl.post_id = p.id AND pl.user_id = $2) AS user_has_liked
+				COALESCE((SELECT ps.comments_count FROM post_stats ps WHERE ps.post_id = p.id), 0) AS comments_count,
+				EXISTS(SELECT 1 FROM post_likes pl WHERE pl.post_id = p.id AND pl.user_id = $2) AS user_has_liked,
+				EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = $2) AS user_has_bookmarked
 			FROM posts p
 			LEFT JOIN users u ON p.user_id = u.id
 			LEFT JOIN categories c ON p.category_id = c.id
-			WHERE p.id = $1
+			WHERE p.id = $1 AND p.deleted_at IS NULL
 		`
 		args = []any{id, currentUserID}
 	} else {
 		query = `
-			SELECT p.id, p.title, p.introduction, p.content, p.category_id, p.status,
-				p.user_id, p.thumbnail_image, p.created_at, p.updated_at, p.version,
-				u.name, u.bio, u.profile_picture, c.name as category,
+			SELECT p.id, p.title, p.introduction, p.content, p.category_id, p.status, p.tags, p.locale,
+				p.user_id, p.thumbnail_image, p.thumbnail_media_id, p.created_at, p.updated_at, p.version,
+				p.published_at, u.name, u.bio, u.profile_picture, c.name as category,
 				(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id) AS likes_count,
-				false AS user_has_liked
+				COALESCE((SELECT ps.comments_count FROM post_stats ps WHERE ps.post_id = p.id), 0) AS comments_count,
+				false AS user_has_liked,
+				false AS user_has_bookmarked
 			FROM posts p
 			LEFT JOIN users u ON p.user_id = u.id
 			LEFT JOIN categories c ON p.category_id = c.id
-			WHERE p.id = $1
+			WHERE p.id = $1 AND p.deleted_at IS NULL
 		`
 		args = []any{id}
 	}
@@ -225,17 +335,23 @@ func (s *PostStore) GetByID(ctx context.Context, id int64, currentUserID int64)
 		&post.Content,
 		&post.CategoryID,
 		&post.Status,
+		pq.Array(&post.Tags),
+		&post.Locale,
 		&post.UserID,
 		&post.ThumbnailImage,
+		&post.ThumbnailMediaID,
 		&post.CreatedAt,
 		&post.UpdatedAt,
 		&post.Version,
+		&post.PublishedAt,
 		&userName,
 		&userBio,
 		&userProfilePicture,
 		&category,
 		&post.LikesCount,
+		&post.CommentsCount,
 		&post.UserHasLiked,
+		&post.UserHasBookmarked,
 	)
 	if err != nil {
 		switch {
@@ -247,6 +363,7 @@ func (s *PostStore) GetByID(ctx context.Context, id int64, currentUserID int64)
 	}
 
 	post.Author = &Author{
+		ID:             post.UserID,
 		Name:           userName.String,
 		Bio:            userBio.String,
 		ProfilePicture: userProfilePicture.String,
@@ -256,27 +373,40 @@ func (s *PostStore) GetByID(ctx context.Context, id int64, currentUserID int64)
 		post.Category = category.String
 	}
 
+	post.CoAuthors, err = getAcceptedCoAuthors(ctx, s.db, post.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &post, nil
 }
 
-func (s *PostStore) Update(ctx context.Context, post *Post) error {
-	query := `
-		UPDATE posts
-		SET title = $1, introduction = $2, content = $3, category_id = $4, thumbnail_image = $5, status = $6,
-		updated_at = NOW(), version = version + 1
-		WHERE id = $7 AND version = $8
-		RETURNING version
-	`
+// updatePostQuery updates a post at the version it was read at, see
+// updatePostArgs for its params
+const updatePostQuery = `
+	UPDATE posts
+	SET title = $1, introduction = $2, content = $3, category_id = $4, thumbnail_image = $5, status = $6,
+	search_language = COALESCE(NULLIF($9, '')::regconfig, search_language), thumbnail_media_id = $10,
+	tags = COALESCE($11::text[], tags), locale = COALESCE(NULLIF($12, ''), locale),
+	updated_at = NOW(), version = version + 1
+	WHERE id = $7 AND version = $8 AND deleted_at IS NULL
+	RETURNING version, updated_at, published_at
+`
+
+func updatePostArgs(post *Post) []any {
+	return []any{
+		post.Title, post.Introduction,
+		post.Content, post.CategoryID,
+		post.ThumbnailImage, post.Status,
+		post.ID, post.Version, post.SearchLanguage, post.ThumbnailMediaID, pq.Array(post.Tags), post.Locale,
+	}
+}
 
+func (s *PostStore) Update(ctx context.Context, post *Post) error {
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
 	defer cancel()
 
-	err := s.db.QueryRowContext(
-		ctx, query,
-		post.Title, post.Introduction,
-		post.Content, post.CategoryID,
-		post.ThumbnailImage, post.Status,
-		post.ID, post.Version).Scan(&post.Version)
+	err := s.db.QueryRowContext(ctx, updatePostQuery, updatePostArgs(post)...).Scan(&post.Version, &post.UpdatedAt, &post.PublishedAt)
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -289,14 +419,18 @@ func (s *PostStore) Update(ctx context.Context, post *Post) error {
 	return nil
 }
 
-func (s *PostStore) Delete(ctx context.Context, id int64) error {
+// Delete moves a post to the trash. Its comments and likes are kept until
+// the post is purged. A version other than 0 makes the delete fail with
+// ErrNotFound when the post was updated since that version.
+func (s *PostStore) Delete(ctx context.Context, id int64, version int) error {
 	query := `
-		DELETE FROM posts WHERE id = $1
+		UPDATE posts SET deleted_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL AND ($2 = 0 OR version = $2)
 	`
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
 	defer cancel()
 
-	res, err := s.db.ExecContext(ctx, query, id)
+	res, err := s.db.ExecContext(ctx, query, id, version)
 	if err != nil {
 		return err
 	}
@@ -321,31 +455,167 @@ func getFeedCountQuery(fq *PaginatedFeedQuery) (string, []any) {
 		LEFT JOIN categories c ON c.id = p.category_id
 	`
 
-	whereConditions := []string{}
-	var queryParams = []any{}
+	whereConditions, queryParams := getFeedFilters(fq, []any{})
 
-	if fq.Search != "" {
-		whereConditions = append(whereConditions, fmt.Sprintf("(p.title ILIKE '%%' || $%d || '%%' OR p.introduction ILIKE '%%' || $%d || '%%')",
-			len(queryParams)+1,
-			len(queryParams)+1,
-		))
-		queryParams = append(queryParams, fq.Search)
+	finalQuery := baseQuery
+	if len(whereConditions) > 0 {
+		finalQuery += " WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
-	if fq.Category != "" {
+	return finalQuery, queryParams
+}
+
+func getSearchQuery(sq *PaginatedSearchQuery) (string, []any) {
+	whereConditions := []string{
+		"p.search_vector @@ q.query",
+		"p.status ILIKE 'published'",
+		"p.deleted_at IS NULL",
+	}
+	var queryParams = []any{
+		sq.Language,
+		sq.Query,
+		sq.Limit,
+		sq.GetOffset(),
+	}
+
+	if sq.Category != "" {
 		whereConditions = append(whereConditions, fmt.Sprintf("c.name = $%d", len(queryParams)+1))
-		queryParams = append(queryParams, fq.Category)
+		queryParams = append(queryParams, sq.Category)
 	}
 
-	if fq.Status != "" {
-		whereConditions = append(whereConditions, fmt.Sprintf("p.status ILIKE $%d", len(queryParams)+1))
-		queryParams = append(queryParams, fq.Status)
+	// ts_headline is expensive, so it only runs on the rows of the requested
+	// page. It runs on escaped text so the <mark> tags it adds are the only
+	// markup in its output.
+	query := `
+		WITH q AS (
+			SELECT websearch_to_tsquery($1::regconfig, $2) AS query
+		), ranked AS (
+			SELECT p.id, p.title, p.introduction, p.content, p.category_id, c.name AS category, p.updated_at,
+				p.thumbnail_image, p.user_id, u.name AS author_name, u.profile_picture, p.status,
+				ps.likes_count, ps.comments_count, ps.trending_score,
+				ts_rank_cd(p.search_vector, q.query) AS rank
+			FROM posts p
+			CROSS JOIN q
+			JOIN post_stats ps ON ps.post_id = p.id
+			LEFT JOIN users u ON u.id = p.user_id
+			LEFT JOIN categories c ON c.id = p.category_id
+			WHERE ` + strings.Join(whereConditions, " AND ") + `
+			ORDER BY rank DESC, p.id DESC
+			LIMIT $3 OFFSET $4
+		)
+		SELECT r.id, r.title, r.introduction, r.category_id, r.category, r.updated_at, r.thumbnail_image,
+			r.user_id, r.author_name, r.profile_picture, r.status,
+			r.likes_count, r.comments_count, r.trending_score, r.rank,
+			ts_headline($1::regconfig, ` + escapeHTML("r.title") + `, q.query,
+				'HighlightAll=true, StartSel=<mark>, StopSel=</mark>'),
+			ts_headline($1::regconfig, ` + escapeHTML("coalesce(r.introduction, '') || ' ' || r.content") + `, q.query,
+				'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" ... "')
+		FROM ranked r
+		CROSS JOIN q
+		ORDER BY r.rank DESC, r.id DESC
+	`
+
+	return query, queryParams
+}
+
+// escapeHTML returns the SQL expression of the text of expr with the HTML
+// special characters escaped
+func escapeHTML(expr string) string {
+	return "replace(replace(replace(replace(replace(" + expr + ", '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), " +
+		"'\"', '&quot;'), '''', '&#39;')"
+}
+
+func getSearchCountQuery(sq *PaginatedSearchQuery) (string, []any) {
+	whereConditions := []string{
+		"p.search_vector @@ websearch_to_tsquery($1::regconfig, $2)",
+		"p.status ILIKE 'published'",
+		"p.deleted_at IS NULL",
+	}
+	var queryParams = []any{
+		sq.Language,
+		sq.Query,
 	}
 
-	finalQuery := baseQuery
-	if len(whereConditions) > 0 {
-		finalQuery += " WHERE " + strings.Join(whereConditions, " AND ")
+	if sq.Category != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("c.name = $%d", len(queryParams)+1))
+		queryParams = append(queryParams, sq.Category)
 	}
 
-	return finalQuery, queryParams
+	query := `
+		SELECT COUNT(*)
+		FROM posts p
+		LEFT JOIN categories c ON c.id = p.category_id
+		WHERE ` + strings.Join(whereConditions, " AND ")
+
+	return query, queryParams
+}
+
+// CheckSearchLanguage returns an error when language is not a text search
+// configuration of the database
+func (s *PostStore) CheckSearchLanguage(ctx context.Context, language string) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var config string
+	return s.db.QueryRowContext(ctx, `SELECT $1::regconfig::text`, language).Scan(&config)
+}
+
+func (s *PostStore) Search(ctx context.Context, sq PaginatedSearchQuery) ([]SearchResult, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	countQuery, countParams := getSearchCountQuery(&sq)
+
+	var total int64
+	if err := s.db.QueryRowContext(ctx, countQuery, countParams...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query, queryParams := getSearchQuery(&sq)
+
+	rows, err := s.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	results := []SearchResult{}
+	for rows.Next() {
+		var item SearchResult
+		var authorName, authorProfilePicture, category sql.NullString
+		err := rows.Scan(
+			&item.ID,
+			&item.Title,
+			&item.Introduction,
+			&item.CategoryID,
+			&category,
+			&item.UpdatedAt,
+			&item.ThumbnailImage,
+			&item.UserID,
+			&authorName,
+			&authorProfilePicture,
+			&item.Status,
+			&item.LikesCount,
+			&item.CommentsCount,
+			&item.TrendingScore,
+			&item.Rank,
+			&item.TitleHighlight,
+			&item.Snippet,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		item.Category = category.String
+		item.AuthorName = authorName.String
+		item.AuthorProfilePicture = authorProfilePicture.String
+
+		results = append(results, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return results, total, nil
 }
@@ -0,0 +1,151 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type PreviewToken struct {
+	ID        int64   `json:"id"`
+	PostID    int64   `json:"post_id"`
+	CreatedBy int64   `json:"created_by"`
+	ExpiresAt string  `json:"expires_at"`
+	RevokedAt *string `json:"revoked_at"`
+	CreatedAt string  `json:"created_at"`
+}
+
+type PreviewTokenStore struct {
+	db *sql.DB
+}
+
+// Create stores the hash of a preview token, the plain token is never persisted
+func (s *PreviewTokenStore) Create(ctx context.Context, token *PreviewToken, hashToken string, exp time.Duration) error {
+	query := `
+		INSERT INTO post_preview_tokens (post_id, token_hash, created_by, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, expires_at, created_at
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return s.db.QueryRowContext(
+		ctx,
+		query,
+		token.PostID,
+		hashToken,
+		token.CreatedBy,
+		time.Now().Add(exp),
+	).Scan(
+		&token.ID,
+		&token.ExpiresAt,
+		&token.CreatedAt,
+	)
+}
+
+func (s *PreviewTokenStore) GetByPostID(ctx context.Context, postID int64) ([]PreviewToken, error) {
+	query := `
+		SELECT id, post_id, COALESCE(created_by, 0), expires_at, revoked_at, created_at
+		FROM post_preview_tokens
+		WHERE post_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []PreviewToken{}
+	for rows.Next() {
+		var t PreviewToken
+		var revokedAt sql.NullString
+		if err := rows.Scan(&t.ID, &t.PostID, &t.CreatedBy, &t.ExpiresAt, &revokedAt, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		if revokedAt.Valid {
+			t.RevokedAt = &revokedAt.String
+		}
+
+		tokens = append(tokens, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// GetPostID resolves a token hash to its post, as long as the token is
+// neither expired nor revoked
+func (s *PreviewTokenStore) GetPostID(ctx context.Context, hashToken string) (int64, error) {
+	query := `
+		SELECT post_id
+		FROM post_preview_tokens
+		WHERE token_hash = $1 AND revoked_at IS NULL AND expires_at > $2
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var postID int64
+	err := s.db.QueryRowContext(ctx, query, hashToken, time.Now()).Scan(&postID)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return 0, ErrNotFound
+		default:
+			return 0, err
+		}
+	}
+
+	return postID, nil
+}
+
+func (s *PreviewTokenStore) Revoke(ctx context.Context, postID, tokenID int64) error {
+	query := `
+		UPDATE post_preview_tokens
+		SET revoked_at = NOW()
+		WHERE id = $1 AND post_id = $2 AND revoked_at IS NULL
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, tokenID, postID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteExpired removes tokens that have been unusable for a week, so
+// authors still see recently expired or revoked links in their list
+func (s *PreviewTokenStore) DeleteExpired(ctx context.Context) (int64, error) {
+	query := `
+		DELETE FROM post_preview_tokens
+		WHERE expires_at < NOW() - INTERVAL '7 days' OR revoked_at < NOW() - INTERVAL '7 days'
+	`
+	ctx, cancel := context.WithTimeout(ctx, BatchQueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
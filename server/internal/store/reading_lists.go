@@ -0,0 +1,368 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+
+	"github.com/lib/pq"
+)
+
+var ErrReadingListOrderMismatch = errors.New("the order must list every post of the reading list exactly once")
+
+// ReadingList is a named, ordered and private collection of posts
+type ReadingList struct {
+	ID          int64  `json:"id"`
+	UserID      int64  `json:"user_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	PostsCount  int64  `json:"posts_count"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type ReadingListPost struct {
+	FeedItem
+	Position int    `json:"position"`
+	AddedAt  string `json:"added_at"`
+}
+
+// ReadingListCursor is the decoded position of a reading list page
+type ReadingListCursor struct {
+	ListID   int64 `json:"l"`
+	Position int   `json:"p"`
+}
+
+type ReadingListStore struct {
+	db *sql.DB
+}
+
+func (s *ReadingListStore) Create(ctx context.Context, list *ReadingList) error {
+	query := `
+		INSERT INTO reading_lists (user_id, name, description)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	err := s.db.QueryRowContext(ctx, query, list.UserID, list.Name, list.Description).Scan(
+		&list.ID,
+		&list.CreatedAt,
+		&list.UpdatedAt,
+	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return ErrUniqueViolation
+	}
+
+	return err
+}
+
+func (s *ReadingListStore) GetByID(ctx context.Context, id int64) (*ReadingList, error) {
+	query := `
+		SELECT rl.id, rl.user_id, rl.name, rl.description,
+			(SELECT COUNT(*) FROM reading_list_posts rlp WHERE rlp.list_id = rl.id),
+			rl.created_at, rl.updated_at
+		FROM reading_lists rl
+		WHERE rl.id = $1
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var list ReadingList
+	err := s.db.QueryRowContext(ctx, query, id).Scan(
+		&list.ID,
+		&list.UserID,
+		&list.Name,
+		&list.Description,
+		&list.PostsCount,
+		&list.CreatedAt,
+		&list.UpdatedAt,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &list, nil
+}
+
+// GetByUserID returns the reading lists of a user by name
+func (s *ReadingListStore) GetByUserID(ctx context.Context, userID int64) ([]ReadingList, error) {
+	query := `
+		SELECT rl.id, rl.user_id, rl.name, rl.description,
+			(SELECT COUNT(*) FROM reading_list_posts rlp WHERE rlp.list_id = rl.id),
+			rl.created_at, rl.updated_at
+		FROM reading_lists rl
+		WHERE rl.user_id = $1
+		ORDER BY rl.name
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lists := []ReadingList{}
+	for rows.Next() {
+		var list ReadingList
+		err := rows.Scan(
+			&list.ID,
+			&list.UserID,
+			&list.Name,
+			&list.Description,
+			&list.PostsCount,
+			&list.CreatedAt,
+			&list.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		lists = append(lists, list)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return lists, nil
+}
+
+func (s *ReadingListStore) Update(ctx context.Context, list *ReadingList) error {
+	query := `
+		UPDATE reading_lists
+		SET name = $1, description = $2, updated_at = NOW()
+		WHERE id = $3
+		RETURNING updated_at
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	err := s.db.QueryRowContext(ctx, query, list.Name, list.Description, list.ID).Scan(&list.UpdatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrNotFound
+		case errors.As(err, &pqErr) && pqErr.Code == "23505":
+			return ErrUniqueViolation
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ReadingListStore) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM reading_lists WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// AddPost appends a post to the end of a reading list. A post already in the
+// list returns ErrUniqueViolation.
+func (s *ReadingListStore) AddPost(ctx context.Context, listID, postID int64) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var position int
+	err := withTx(s.db, ctx, func(tx *sql.Tx) error {
+		if err := lockReadingList(ctx, tx, listID); err != nil {
+			return err
+		}
+
+		query := `
+			INSERT INTO reading_list_posts (list_id, post_id, position)
+			SELECT $1, $2, COALESCE(MAX(position), 0) + 1
+			FROM reading_list_posts
+			WHERE list_id = $1
+			RETURNING position
+		`
+		err := tx.QueryRowContext(ctx, query, listID, postID).Scan(&position)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return ErrUniqueViolation
+		}
+
+		return err
+	})
+
+	return position, err
+}
+
+// RemovePost takes a post out of a reading list and closes the gap it leaves
+func (s *ReadingListStore) RemovePost(ctx context.Context, listID, postID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return withTx(s.db, ctx, func(tx *sql.Tx) error {
+		if err := lockReadingList(ctx, tx, listID); err != nil {
+			return err
+		}
+
+		var position int
+		query := `
+			DELETE FROM reading_list_posts
+			WHERE list_id = $1 AND post_id = $2
+			RETURNING position
+		`
+		err := tx.QueryRowContext(ctx, query, listID, postID).Scan(&position)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return ErrNotFound
+			default:
+				return err
+			}
+		}
+
+		query = `
+			UPDATE reading_list_posts SET position = position - 1
+			WHERE list_id = $1 AND position > $2
+		`
+		_, err = tx.ExecContext(ctx, query, listID, position)
+		return err
+	})
+}
+
+// Reorder sets the order of the posts of a reading list. postIDs must hold
+// every post of the list, hidden ones included.
+func (s *ReadingListStore) Reorder(ctx context.Context, listID int64, postIDs []int64) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return withTx(s.db, ctx, func(tx *sql.Tx) error {
+		if err := lockReadingList(ctx, tx, listID); err != nil {
+			return err
+		}
+
+		rows, err := tx.QueryContext(ctx, `SELECT post_id FROM reading_list_posts WHERE list_id = $1`, listID)
+		if err != nil {
+			return err
+		}
+
+		current := []int64{}
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			current = append(current, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		requested := slices.Clone(postIDs)
+		slices.Sort(current)
+		slices.Sort(requested)
+		if !slices.Equal(current, requested) {
+			return ErrReadingListOrderMismatch
+		}
+
+		query := `
+			UPDATE reading_list_posts rlp
+			SET position = o.position
+			FROM unnest($2::bigint[]) WITH ORDINALITY AS o(post_id, position)
+			WHERE rlp.list_id = $1 AND rlp.post_id = o.post_id
+		`
+		_, err = tx.ExecContext(ctx, query, listID, pq.Array(postIDs))
+		return err
+	})
+}
+
+// GetPosts returns the posts of a reading list after the given position, in
+// order. Trashed posts, and drafts of other authors than the list owner, are
+// skipped but keep their place.
+func (s *ReadingListStore) GetPosts(ctx context.Context, listID int64, after int, limit int) ([]ReadingListPost, bool, error) {
+	query := `
+		SELECT p.id, p.title, p.introduction, p.category_id, c.name AS category, p.updated_at, p.thumbnail_image,
+			p.user_id, u.name, u.profile_picture, p.status, ps.likes_count, ps.comments_count, ps.trending_score,
+			rlp.position, rlp.added_at
+		FROM reading_list_posts rlp
+		JOIN reading_lists rl ON rl.id = rlp.list_id
+		JOIN posts p ON p.id = rlp.post_id
+		JOIN post_stats ps ON ps.post_id = p.id
+		LEFT JOIN users u ON u.id = p.user_id
+		LEFT JOIN categories c ON c.id = p.category_id
+		WHERE rlp.list_id = $1 AND rlp.position > $2 AND p.deleted_at IS NULL
+			AND (p.status ILIKE 'published' OR p.user_id = rl.user_id)
+		ORDER BY rlp.position
+		LIMIT $3
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, listID, after, limit+1)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	posts := []ReadingListPost{}
+	for rows.Next() {
+		var post ReadingListPost
+		fields := append(feedItemFields(&post.FeedItem), &post.Position, &post.AddedAt)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, false, err
+		}
+
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(posts) > limit
+	if hasMore {
+		posts = posts[:limit]
+	}
+
+	return posts, hasMore, nil
+}
+
+func lockReadingList(ctx context.Context, tx *sql.Tx, listID int64) error {
+	var id int64
+	err := tx.QueryRowContext(ctx, `SELECT id FROM reading_lists WHERE id = $1 FOR UPDATE`, listID).Scan(&id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package store
+
+import "context"
+
+type RelatedPost struct {
+	FeedItem
+	Score float64 `json:"score"`
+}
+
+// relatedCandidatesLimit bounds each source of candidates so ranking stays
+// cheap on large categories and popular posts
+const relatedCandidatesLimit = 200
+
+// GetRelated ranks the published posts related to a post. Candidates come
+// from the same category, from titles similar to its title (pg_trgm) and from
+// posts liked by the same readers, and are scored on all of these plus
+// recency.
+func (s *PostStore) GetRelated(ctx context.Context, postID int64, limit int) ([]RelatedPost, error) {
+	query := `
+		WITH src AS (
+			SELECT p.id, p.title, p.category_id, ps.likes_count
+			FROM posts p
+			JOIN post_stats ps ON ps.post_id = p.id
+			WHERE p.id = $1
+		), co_likes AS (
+			SELECT pl.post_id, COUNT(*) AS shared
+			FROM post_likes pl
+			WHERE pl.user_id IN (SELECT user_id FROM post_likes WHERE post_id = $1)
+				AND pl.post_id <> $1
+			GROUP BY pl.post_id
+			ORDER BY shared DESC
+			LIMIT $3
+		), candidates AS (
+			(SELECT p.id FROM posts p, src
+				WHERE p.category_id = src.category_id
+					AND p.id <> src.id AND p.deleted_at IS NULL AND p.status = 'Published'
+				ORDER BY p.created_at DESC
+				LIMIT $3)
+			UNION
+			(SELECT p.id FROM posts p, src
+				WHERE p.title % src.title
+					AND p.id <> src.id AND p.deleted_at IS NULL AND p.status = 'Published'
+				ORDER BY similarity(p.title, src.title) DESC
+				LIMIT $3)
+			UNION
+			SELECT post_id FROM co_likes
+		)
+		SELECT p.id, p.title, p.introduction, p.category_id, c.name AS category, p.updated_at, p.thumbnail_image,
+			p.user_id, u.name, u.profile_picture, p.status, ps.likes_count, ps.comments_count, ps.trending_score,
+			-- weights: category 3, title similarity 4, co-likes 2, recency 1
+			3.0 * COALESCE(p.category_id = src.category_id, false)::int
+				+ 4.0 * similarity(p.title, src.title)
+				+ 2.0 * COALESCE(cl.shared / SQRT(GREATEST(src.likes_count, 1) * GREATEST(ps.likes_count, 1)), 0)
+				+ 1.0 * EXP(-EXTRACT(EPOCH FROM NOW() - p.created_at) / 2592000.0) AS score
+		FROM candidates cand
+		JOIN posts p ON p.id = cand.id
+		JOIN post_stats ps ON ps.post_id = p.id
+		CROSS JOIN src
+		LEFT JOIN co_likes cl ON cl.post_id = p.id
+		LEFT JOIN users u ON u.id = p.user_id
+		LEFT JOIN categories c ON c.id = p.category_id
+		WHERE p.deleted_at IS NULL AND p.status = 'Published'
+		ORDER BY score DESC, p.id DESC
+		LIMIT $2
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, postID, limit, relatedCandidatesLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	posts := []RelatedPost{}
+	for rows.Next() {
+		var post RelatedPost
+		fields := append(feedItemFields(&post.FeedItem), &post.Score)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
@@ -0,0 +1,294 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrInvalidTransition = errors.New("the post cannot move to this status from its current one")
+	ErrReviewRequired    = errors.New("posts in this category must be approved by a moderator before they are published")
+)
+
+// Review actions recorded in the history of a post
+const (
+	ReviewActionSubmitted        = "submitted"
+	ReviewActionWithdrawn        = "withdrawn"
+	ReviewActionStarted          = "started"
+	ReviewActionApproved         = "approved"
+	ReviewActionChangesRequested = "changes_requested"
+	ReviewActionComment          = "comment"
+	ReviewActionPublished        = "published"
+)
+
+type Review struct {
+	ID         int64  `json:"id"`
+	PostID     int64  `json:"post_id"`
+	UserID     *int64 `json:"user_id"`
+	UserName   string `json:"user_name"`
+	Action     string `json:"action"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Note       string `json:"note"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type ReviewQueueItem struct {
+	FeedItem
+	SubmittedAt  string `json:"submitted_at"`
+	ReviewerID   *int64 `json:"reviewer_id"`
+	ReviewerName string `json:"reviewer_name"`
+}
+
+// IsUnderReview reports whether status is one of the review workflow steps
+func IsUnderReview(status string) bool {
+	for _, s := range []string{PostStatusSubmitted, PostStatusInReview, PostStatusApproved, PostStatusChangesRequested} {
+		if strings.EqualFold(status, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StatusAfterEdit returns the status an edit leaves a post in. An approval
+// only covers the reviewed version, so when the author changes an approved
+// post, or a published post of a category that requires review, it goes
+// back to review even if the edit asks to publish it. requiresReview is about
+// the category the post is edited into.
+func StatusAfterEdit(from, to string, contentChanged, requiresReview, isModerator bool) string {
+	if isModerator || !contentChanged {
+		return to
+	}
+
+	approved := strings.EqualFold(from, PostStatusApproved) &&
+		(strings.EqualFold(to, PostStatusApproved) || strings.EqualFold(to, PostStatusPublished))
+	published := requiresReview && strings.EqualFold(from, PostStatusPublished) && strings.EqualFold(to, PostStatusPublished)
+	if approved || published {
+		return PostStatusSubmitted
+	}
+
+	return to
+}
+
+// CheckStatusTransition decides whether the author of a post, or a
+// moderator, may move it from one status to another by editing it. The
+// review steps in between (In Review, Approved, Changes requested) are only
+// reached through moderator reviews, not through edits. contentChanged tells
+// whether the edit changes more than the status, see StatusAfterEdit.
+func CheckStatusTransition(from, to string, contentChanged, requiresReview, isModerator bool) error {
+	if strings.EqualFold(from, to) {
+		// edits keep the status unless they change what was reviewed
+		if contentChanged && !isModerator && (strings.EqualFold(to, PostStatusApproved) ||
+			requiresReview && strings.EqualFold(to, PostStatusPublished)) {
+			return ErrReviewRequired
+		}
+		return nil
+	}
+
+	switch {
+	case strings.EqualFold(to, PostStatusDraft):
+		// authors may always take a post back, withdrawing it from review
+		return nil
+	case strings.EqualFold(to, PostStatusSubmitted):
+		// approved and published posts go back to review when their author
+		// edits them
+		if strings.EqualFold(from, PostStatusDraft) || strings.EqualFold(from, PostStatusChangesRequested) ||
+			strings.EqualFold(from, PostStatusApproved) || strings.EqualFold(from, PostStatusPublished) {
+			return nil
+		}
+		return ErrInvalidTransition
+	case strings.EqualFold(to, PostStatusPublished):
+		if isModerator || !requiresReview {
+			return nil
+		}
+		// only the status of an approved post may change when publishing it
+		if strings.EqualFold(from, PostStatusApproved) && !contentChanged {
+			return nil
+		}
+		return ErrReviewRequired
+	default:
+		return ErrInvalidTransition
+	}
+}
+
+// ReviewActionForStatusChange returns the history entry recorded when an
+// edit changes the status of a post, or an empty string when there is none
+func ReviewActionForStatusChange(from, to string) string {
+	switch {
+	case strings.EqualFold(from, to):
+		return ""
+	case strings.EqualFold(to, PostStatusSubmitted):
+		return ReviewActionSubmitted
+	case strings.EqualFold(to, PostStatusDraft) && IsUnderReview(from):
+		return ReviewActionWithdrawn
+	case strings.EqualFold(to, PostStatusPublished) && IsUnderReview(from):
+		return ReviewActionPublished
+	default:
+		return ""
+	}
+}
+
+type ReviewStore struct {
+	db *sql.DB
+}
+
+func (s *ReviewStore) Create(ctx context.Context, review *Review) error {
+	query := `
+		INSERT INTO post_reviews (post_id, user_id, action, from_status, to_status, note)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return s.db.QueryRowContext(
+		ctx, query,
+		review.PostID, review.UserID, review.Action, review.FromStatus, review.ToStatus, review.Note,
+	).Scan(&review.ID, &review.CreatedAt)
+}
+
+// Transition moves a post to review.ToStatus and records the review in one
+// transaction. The post must still be in one of the from statuses, otherwise
+// ErrInvalidTransition is returned, so two moderators cannot act on the same
+// step concurrently.
+func (s *ReviewStore) Transition(ctx context.Context, review *Review, from []string) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return withTx(s.db, ctx, func(tx *sql.Tx) error {
+		query := `
+			WITH cur AS (
+				SELECT id, status FROM posts
+				WHERE id = $1 AND deleted_at IS NULL AND status = ANY($3)
+				FOR UPDATE
+			)
+			UPDATE posts p
+			SET status = $2, updated_at = NOW(), version = p.version + 1
+			FROM cur
+			WHERE p.id = cur.id
+			RETURNING cur.status
+		`
+		err := tx.QueryRowContext(ctx, query, review.PostID, review.ToStatus, pq.Array(from)).Scan(&review.FromStatus)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return ErrInvalidTransition
+			default:
+				return err
+			}
+		}
+
+		query = `
+			INSERT INTO post_reviews (post_id, user_id, action, from_status, to_status, note)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, created_at
+		`
+		return tx.QueryRowContext(
+			ctx, query,
+			review.PostID, review.UserID, review.Action, review.FromStatus, review.ToStatus, review.Note,
+		).Scan(&review.ID, &review.CreatedAt)
+	})
+}
+
+// GetByPostID returns the review history of a post, oldest first
+func (s *ReviewStore) GetByPostID(ctx context.Context, postID int64) ([]Review, error) {
+	query := `
+		SELECT r.id, r.post_id, r.user_id, COALESCE(u.name, ''), r.action, r.from_status, r.to_status, r.note, r.created_at
+		FROM post_reviews r
+		LEFT JOIN users u ON u.id = r.user_id
+		WHERE r.post_id = $1
+		ORDER BY r.created_at, r.id
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []Review{}
+	for rows.Next() {
+		var r Review
+		err := rows.Scan(&r.ID, &r.PostID, &r.UserID, &r.UserName, &r.Action, &r.FromStatus, &r.ToStatus, &r.Note, &r.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		reviews = append(reviews, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+// GetQueue returns the posts waiting for a review in the given statuses,
+// the longest waiting first, along with their total count
+func (s *ReviewStore) GetQueue(ctx context.Context, statuses []string, page, limit int) ([]ReviewQueueItem, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM posts WHERE deleted_at IS NULL AND status = ANY($1)`
+	if err := s.db.QueryRowContext(ctx, countQuery, pq.Array(statuses)).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT p.id, p.title, p.introduction, p.category_id, c.name AS category, p.updated_at, p.thumbnail_image,
+			p.user_id, u.name, u.profile_picture, p.status, ps.likes_count, ps.comments_count, ps.trending_score,
+			COALESCE(submitted.created_at, p.updated_at) AS submitted_at,
+			started.user_id, COALESCE(reviewer.name, '')
+		FROM posts p
+		JOIN post_stats ps ON ps.post_id = p.id
+		LEFT JOIN users u ON u.id = p.user_id
+		LEFT JOIN categories c ON c.id = p.category_id
+		LEFT JOIN LATERAL (
+			SELECT r.created_at FROM post_reviews r
+			WHERE r.post_id = p.id AND r.action = 'submitted'
+			ORDER BY r.created_at DESC, r.id DESC
+			LIMIT 1
+		) submitted ON true
+		LEFT JOIN LATERAL (
+			SELECT r.user_id FROM post_reviews r
+			WHERE r.post_id = p.id AND r.action = 'started' AND p.status = 'In Review'
+			ORDER BY r.created_at DESC, r.id DESC
+			LIMIT 1
+		) started ON true
+		LEFT JOIN users reviewer ON reviewer.id = started.user_id
+		WHERE p.deleted_at IS NULL AND p.status = ANY($1)
+		ORDER BY submitted_at, p.id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(statuses), limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []ReviewQueueItem{}
+	for rows.Next() {
+		var item ReviewQueueItem
+		fields := append(feedItemFields(&item.FeedItem), &item.SubmittedAt, &item.ReviewerID, &item.ReviewerName)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, 0, err
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckStatusTransition(t *testing.T) {
+	tests := []struct {
+		name           string
+		from, to       string
+		contentChanged bool
+		requiresReview bool
+		isModerator    bool
+		want           error
+	}{
+		{"keep a draft", PostStatusDraft, PostStatusDraft, true, true, false, nil},
+		{"publish a draft", PostStatusDraft, PostStatusPublished, true, false, false, nil},
+		{"publish a draft needing review", PostStatusDraft, PostStatusPublished, true, true, false, ErrReviewRequired},
+		{"moderator publishes a draft needing review", PostStatusDraft, PostStatusPublished, true, true, true, nil},
+		{"submit a draft", PostStatusDraft, PostStatusSubmitted, true, true, false, nil},
+		{"resubmit after changes requested", PostStatusChangesRequested, PostStatusSubmitted, true, true, false, nil},
+		{"withdraw from review", PostStatusInReview, PostStatusDraft, false, true, false, nil},
+		{"submit a post in review", PostStatusInReview, PostStatusSubmitted, false, true, false, ErrInvalidTransition},
+		{"approve by an edit", PostStatusSubmitted, PostStatusApproved, false, true, true, ErrInvalidTransition},
+		{"publish an approved post", PostStatusApproved, PostStatusPublished, false, true, false, nil},
+		{"publish an edited approved post", PostStatusApproved, PostStatusPublished, true, true, false, ErrReviewRequired},
+		{"edit an approved post", PostStatusApproved, PostStatusApproved, true, true, false, ErrReviewRequired},
+		{"send an edited approved post to review", PostStatusApproved, PostStatusSubmitted, true, true, false, nil},
+		{"publish a submitted post", PostStatusSubmitted, PostStatusPublished, false, true, false, ErrReviewRequired},
+		{"edit a published post", PostStatusPublished, PostStatusPublished, true, false, false, nil},
+		{"edit a published post needing review", PostStatusPublished, PostStatusPublished, true, true, false, ErrReviewRequired},
+		{"moderator edits a published post needing review", PostStatusPublished, PostStatusPublished, true, true, true, nil},
+		{"send an edited published post to review", PostStatusPublished, PostStatusSubmitted, true, true, false, nil},
+		{"unpublish", PostStatusPublished, PostStatusDraft, false, true, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckStatusTransition(tt.from, tt.to, tt.contentChanged, tt.requiresReview, tt.isModerator)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("CheckStatusTransition(%s, %s) = %v; want %v", tt.from, tt.to, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusAfterEdit(t *testing.T) {
+	tests := []struct {
+		name           string
+		from, to       string
+		contentChanged bool
+		requiresReview bool
+		isModerator    bool
+		want           string
+	}{
+		{"publish an approved post", PostStatusApproved, PostStatusPublished, false, true, false, PostStatusPublished},
+		{"publish an edited approved post", PostStatusApproved, PostStatusPublished, true, true, false, PostStatusSubmitted},
+		{"edit an approved post", PostStatusApproved, PostStatusApproved, true, false, false, PostStatusSubmitted},
+		{"edit a published post", PostStatusPublished, PostStatusPublished, true, false, false, PostStatusPublished},
+		{"edit a published post needing review", PostStatusPublished, PostStatusPublished, true, true, false, PostStatusSubmitted},
+		{"moderator edits a published post needing review", PostStatusPublished, PostStatusPublished, true, true, true, PostStatusPublished},
+		{"unpublish an edited post", PostStatusPublished, PostStatusDraft, true, true, false, PostStatusDraft},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StatusAfterEdit(tt.from, tt.to, tt.contentChanged, tt.requiresReview, tt.isModerator)
+			if got != tt.want {
+				t.Errorf("StatusAfterEdit(%s, %s) = %s; want %s", tt.from, tt.to, got, tt.want)
+			}
+			if err := CheckStatusTransition(tt.from, got, tt.contentChanged, tt.requiresReview, tt.isModerator); err != nil {
+				t.Errorf("the status after the edit is not a legal transition: %v", err)
+			}
+		})
+	}
+}
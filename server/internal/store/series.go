@@ -0,0 +1,402 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+
+	"github.com/lib/pq"
+)
+
+var ErrSeriesOrderMismatch = errors.New("the order must list every post of the series exactly once")
+
+type Series struct {
+	ID          int64  `json:"id"`
+	UserID      int64  `json:"user_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	AuthorName  string `json:"author_name"`
+	// PostsCount only counts the published posts of the series
+	PostsCount int64  `json:"posts_count"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+type SeriesPost struct {
+	FeedItem
+	// Part is the rank of the post among the parts visible to the reader,
+	// Position is its stored place in the series
+	Part     int `json:"part"`
+	Position int `json:"position"`
+}
+
+type SeriesPostRef struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+// SeriesNavigation places a post within its series
+type SeriesNavigation struct {
+	SeriesID int64          `json:"series_id"`
+	Title    string         `json:"title"`
+	Part     int            `json:"part"`
+	Total    int            `json:"total"`
+	Previous *SeriesPostRef `json:"previous"`
+	Next     *SeriesPostRef `json:"next"`
+}
+
+// SeriesCursor is the decoded position of a series landing page
+type SeriesCursor struct {
+	SeriesID int64 `json:"s"`
+	Position int   `json:"p"`
+}
+
+type SeriesStore struct {
+	db *sql.DB
+}
+
+func (s *SeriesStore) Create(ctx context.Context, series *Series) error {
+	query := `
+		INSERT INTO series (user_id, title, description)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return s.db.QueryRowContext(ctx, query, series.UserID, series.Title, series.Description).Scan(
+		&series.ID,
+		&series.CreatedAt,
+		&series.UpdatedAt,
+	)
+}
+
+func (s *SeriesStore) GetByID(ctx context.Context, id int64) (*Series, error) {
+	query := `
+		SELECT se.id, se.user_id, se.title, se.description, COALESCE(u.name, ''),
+			(SELECT COUNT(*) FROM series_posts sp
+				JOIN posts p ON p.id = sp.post_id
+				WHERE sp.series_id = se.id AND p.deleted_at IS NULL AND p.status ILIKE 'published'),
+			se.created_at, se.updated_at
+		FROM series se
+		LEFT JOIN users u ON u.id = se.user_id
+		WHERE se.id = $1
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var series Series
+	err := s.db.QueryRowContext(ctx, query, id).Scan(
+		&series.ID,
+		&series.UserID,
+		&series.Title,
+		&series.Description,
+		&series.AuthorName,
+		&series.PostsCount,
+		&series.CreatedAt,
+		&series.UpdatedAt,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &series, nil
+}
+
+func (s *SeriesStore) Update(ctx context.Context, series *Series) error {
+	query := `
+		UPDATE series
+		SET title = $1, description = $2, updated_at = NOW()
+		WHERE id = $3
+		RETURNING updated_at
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	err := s.db.QueryRowContext(ctx, query, series.Title, series.Description, series.ID).Scan(&series.UpdatedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete removes a series, its posts stay in place
+func (s *SeriesStore) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM series WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// AddPost appends a post to the end of a series. A post that already belongs
+// to a series returns ErrUniqueViolation.
+func (s *SeriesStore) AddPost(ctx context.Context, seriesID, postID int64) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var position int
+	err := withTx(s.db, ctx, func(tx *sql.Tx) error {
+		if err := lockSeries(ctx, tx, seriesID); err != nil {
+			return err
+		}
+
+		query := `
+			INSERT INTO series_posts (series_id, post_id, position)
+			SELECT $1, $2, COALESCE(MAX(position), 0) + 1
+			FROM series_posts
+			WHERE series_id = $1
+			RETURNING position
+		`
+		err := tx.QueryRowContext(ctx, query, seriesID, postID).Scan(&position)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return ErrUniqueViolation
+		}
+
+		return err
+	})
+
+	return position, err
+}
+
+// RemovePost takes a post out of a series and closes the gap it leaves
+func (s *SeriesStore) RemovePost(ctx context.Context, seriesID, postID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return withTx(s.db, ctx, func(tx *sql.Tx) error {
+		if err := lockSeries(ctx, tx, seriesID); err != nil {
+			return err
+		}
+
+		var position int
+		query := `
+			DELETE FROM series_posts
+			WHERE series_id = $1 AND post_id = $2
+			RETURNING position
+		`
+		err := tx.QueryRowContext(ctx, query, seriesID, postID).Scan(&position)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return ErrNotFound
+			default:
+				return err
+			}
+		}
+
+		query = `
+			UPDATE series_posts SET position = position - 1
+			WHERE series_id = $1 AND position > $2
+		`
+		_, err = tx.ExecContext(ctx, query, seriesID, position)
+		return err
+	})
+}
+
+// Reorder sets the order of the posts of a series. postIDs must hold every
+// post of the series, drafts and trashed posts included.
+func (s *SeriesStore) Reorder(ctx context.Context, seriesID int64, postIDs []int64) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return withTx(s.db, ctx, func(tx *sql.Tx) error {
+		if err := lockSeries(ctx, tx, seriesID); err != nil {
+			return err
+		}
+
+		rows, err := tx.QueryContext(ctx, `SELECT post_id FROM series_posts WHERE series_id = $1`, seriesID)
+		if err != nil {
+			return err
+		}
+
+		current := []int64{}
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			current = append(current, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		requested := slices.Clone(postIDs)
+		slices.Sort(current)
+		slices.Sort(requested)
+		if !slices.Equal(current, requested) {
+			return ErrSeriesOrderMismatch
+		}
+
+		query := `
+			UPDATE series_posts sp
+			SET position = o.position
+			FROM unnest($2::bigint[]) WITH ORDINALITY AS o(post_id, position)
+			WHERE sp.series_id = $1 AND sp.post_id = o.post_id
+		`
+		_, err = tx.ExecContext(ctx, query, seriesID, pq.Array(postIDs))
+		return err
+	})
+}
+
+// GetPosts returns the posts of a series after the given position, in order.
+// Drafts are skipped unless includeDrafts is set, trashed posts always are.
+func (s *SeriesStore) GetPosts(ctx context.Context, seriesID int64, after int, limit int, includeDrafts bool) ([]SeriesPost, bool, error) {
+	query := `
+		WITH visible AS (
+			SELECT sp.post_id, sp.position, ROW_NUMBER() OVER (ORDER BY sp.position) AS part
+			FROM series_posts sp
+			JOIN posts p ON p.id = sp.post_id
+			WHERE sp.series_id = $1 AND p.deleted_at IS NULL
+				AND ($2 OR p.status ILIKE 'published')
+		)
+		SELECT p.id, p.title, p.introduction, p.category_id, c.name AS category, p.updated_at, p.thumbnail_image,
+			p.user_id, u.name, u.profile_picture, p.status, ps.likes_count, ps.comments_count, ps.trending_score,
+			v.part, v.position
+		FROM visible v
+		JOIN posts p ON p.id = v.post_id
+		JOIN post_stats ps ON ps.post_id = p.id
+		LEFT JOIN users u ON u.id = p.user_id
+		LEFT JOIN categories c ON c.id = p.category_id
+		WHERE v.position > $3
+		ORDER BY v.position
+		LIMIT $4
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, seriesID, includeDrafts, after, limit+1)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	posts := []SeriesPost{}
+	for rows.Next() {
+		var post SeriesPost
+		fields := append(feedItemFields(&post.FeedItem), &post.Part, &post.Position)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, false, err
+		}
+
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(posts) > limit
+	if hasMore {
+		posts = posts[:limit]
+	}
+
+	return posts, hasMore, nil
+}
+
+// GetNavigation places a post within its series, counting the parts the
+// reader can see. The post itself always counts. Posts outside of any series
+// return ErrNotFound.
+func (s *SeriesStore) GetNavigation(ctx context.Context, postID int64, includeDrafts bool) (*SeriesNavigation, error) {
+	query := `
+		WITH cur AS (
+			SELECT series_id FROM series_posts WHERE post_id = $1
+		), visible AS (
+			SELECT sp.post_id, p.title,
+				ROW_NUMBER() OVER (ORDER BY sp.position) AS part,
+				COUNT(*) OVER () AS total
+			FROM series_posts sp
+			JOIN cur ON cur.series_id = sp.series_id
+			JOIN posts p ON p.id = sp.post_id
+			WHERE p.deleted_at IS NULL
+				AND ($2 OR p.status ILIKE 'published' OR p.id = $1)
+		)
+		SELECT se.id, se.title, v.part, v.total,
+			prev.post_id, prev.title, next.post_id, next.title
+		FROM visible v
+		JOIN series se ON se.id = (SELECT series_id FROM cur)
+		LEFT JOIN visible prev ON prev.part = v.part - 1
+		LEFT JOIN visible next ON next.part = v.part + 1
+		WHERE v.post_id = $1
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var nav SeriesNavigation
+	var prevID, nextID sql.NullInt64
+	var prevTitle, nextTitle sql.NullString
+	err := s.db.QueryRowContext(ctx, query, postID, includeDrafts).Scan(
+		&nav.SeriesID,
+		&nav.Title,
+		&nav.Part,
+		&nav.Total,
+		&prevID,
+		&prevTitle,
+		&nextID,
+		&nextTitle,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if prevID.Valid {
+		nav.Previous = &SeriesPostRef{ID: prevID.Int64, Title: prevTitle.String}
+	}
+
+	if nextID.Valid {
+		nav.Next = &SeriesPostRef{ID: nextID.Int64, Title: nextTitle.String}
+	}
+
+	return &nav, nil
+}
+
+func lockSeries(ctx context.Context, tx *sql.Tx, seriesID int64) error {
+	var id int64
+	err := tx.QueryRowContext(ctx, `SELECT id FROM series WHERE id = $1 FOR UPDATE`, seriesID).Scan(&id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
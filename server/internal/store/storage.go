@@ -11,6 +11,8 @@ var (
 	ErrNotFound          = errors.New("resource not found")
 	ErrUniqueViolation   = errors.New("unique violation")
 	QueryTimeoutDuration = time.Second * 5
+	// BatchQueryTimeoutDuration is used by background jobs that touch many rows
+	BatchQueryTimeoutDuration = time.Minute
 )
 
 type Storage struct {
@@ -18,8 +20,23 @@ type Storage struct {
 		GetByID(context.Context, int64, int64) (*Post, error)
 		Create(context.Context, *Post) error
 		Update(context.Context, *Post) error
-		Delete(context.Context, int64) error
+		Delete(ctx context.Context, id int64, version int) error
 		GetFeed(context.Context, PaginatedFeedQuery) ([]FeedItem, int64, error)
+		GetFeedByCursor(context.Context, PaginatedFeedQuery, *FeedCursor) ([]FeedItem, bool, error)
+		CountFeed(context.Context, PaginatedFeedQuery) (int64, error)
+		GetPinned(context.Context, PaginatedFeedQuery) ([]FeedItem, error)
+		RefreshTrendingScores(context.Context) (int64, error)
+		Search(context.Context, PaginatedSearchQuery) ([]SearchResult, int64, error)
+		CheckSearchLanguage(ctx context.Context, language string) error
+		GetRelated(ctx context.Context, postID int64, limit int) ([]RelatedPost, error)
+		GetArchive(context.Context, ArchiveQuery) ([]ArchiveMonth, error)
+		GetIDByImportKey(ctx context.Context, userID int64, key string) (int64, error)
+		GetForExport(ctx context.Context, userID int64) ([]ExportedPost, error)
+		GetTrash(ctx context.Context, userID int64, page, limit int) ([]TrashedPost, int64, error)
+		GetTrashedByID(context.Context, int64) (*TrashedPost, error)
+		Restore(context.Context, int64) error
+		Purge(context.Context, int64) error
+		PurgeDeletedBefore(context.Context, time.Time) (int64, error)
 	}
 
 	Users interface {
@@ -29,13 +46,26 @@ type Storage struct {
 		Update(context.Context, *User) (int64, error)
 		Delete(context.Context, int64) error
 		GetByEmail(context.Context, string) (*User, error)
+		GetByUsername(context.Context, string) (*User, error)
 		GetByID(context.Context, int64) (*User, error)
 		UpdatePassword(ctx context.Context, user *User) error
 	}
 
+	PostDrafts interface {
+		Get(ctx context.Context, postID int64) (*PostDraft, error)
+		Save(context.Context, *PostDraft) error
+		Publish(ctx context.Context, post *Post, revision int) error
+		Delete(ctx context.Context, postID int64) error
+	}
+
 	Comments interface {
 		Create(context.Context, *Comment) error
-		GetByPostID(context.Context, int64) ([]Comment, error)
+		GetByPostID(ctx context.Context, postID, viewerID int64) ([]Comment, error)
+		GetPage(ctx context.Context, postID, viewerID int64, sort string, cursor *CommentCursor, limit int) ([]Comment, bool, error)
+		GetByID(context.Context, int64) (*Comment, error)
+		Update(ctx context.Context, comment *Comment, editorID int64) error
+		Delete(context.Context, int64) (bool, error)
+		GetEdits(ctx context.Context, commentID int64) ([]CommentEdit, error)
 	}
 
 	Categories interface {
@@ -54,24 +84,151 @@ type Storage struct {
 		HasUserLiked(ctx context.Context, postID, userID int64) (bool, error)
 	}
 
+	CommentLikes interface {
+		LikeComment(ctx context.Context, commentID, userID int64) (bool, error)
+		UnlikeComment(ctx context.Context, commentID, userID int64) error
+	}
+
+	ContentVersion interface {
+		Get(ctx context.Context) (int64, error)
+	}
+
 	Notifications interface {
 		Create(ctx context.Context, notification *Notification) error
+		CreateForFollowers(ctx context.Context, notification *Notification) ([]int64, error)
 		Get(ctx context.Context, userID int64, page, limit int) ([]Notification, int64, error)
 		GetByUserID(ctx context.Context, userID int64, limit, offset int) ([]*Notification, error)
 		CountUnread(ctx context.Context, userID int64) (int64, error)
 		MarkAsRead(ctx context.Context, id int64, userID int64) error
 		MarkAllAsRead(ctx context.Context, userID int64) error
 	}
+
+	Follows interface {
+		Follow(ctx context.Context, followerID, followeeID int64) error
+		Unfollow(ctx context.Context, followerID, followeeID int64) error
+		IsFollowing(ctx context.Context, followerID, followeeID int64) (bool, error)
+		GetCounts(ctx context.Context, userID int64) (*FollowCounts, error)
+		GetFollowers(ctx context.Context, userID int64, page, limit int) ([]FollowUser, int64, error)
+		GetFollowing(ctx context.Context, userID int64, page, limit int) ([]FollowUser, int64, error)
+	}
+
+	Series interface {
+		Create(context.Context, *Series) error
+		GetByID(context.Context, int64) (*Series, error)
+		Update(context.Context, *Series) error
+		Delete(context.Context, int64) error
+		AddPost(ctx context.Context, seriesID, postID int64) (int, error)
+		RemovePost(ctx context.Context, seriesID, postID int64) error
+		Reorder(ctx context.Context, seriesID int64, postIDs []int64) error
+		GetPosts(ctx context.Context, seriesID int64, after int, limit int, includeDrafts bool) ([]SeriesPost, bool, error)
+		GetNavigation(ctx context.Context, postID int64, includeDrafts bool) (*SeriesNavigation, error)
+	}
+
+	CoAuthors interface {
+		Invite(context.Context, *CoAuthor) error
+		Accept(ctx context.Context, postID, userID int64) error
+		UpdatePermission(ctx context.Context, postID, userID int64, permission string) error
+		Remove(ctx context.Context, postID, userID int64) error
+		GetByPostID(ctx context.Context, postID int64) ([]CoAuthor, error)
+	}
+
+	Reviews interface {
+		Create(context.Context, *Review) error
+		Transition(ctx context.Context, review *Review, from []string) error
+		GetByPostID(ctx context.Context, postID int64) ([]Review, error)
+		GetQueue(ctx context.Context, statuses []string, page, limit int) ([]ReviewQueueItem, int64, error)
+	}
+
+	Bookmarks interface {
+		Bookmark(ctx context.Context, postID, userID int64) error
+		Unbookmark(ctx context.Context, postID, userID int64) error
+		HasUserBookmarked(ctx context.Context, postID, userID int64) (bool, error)
+		GetByUserID(ctx context.Context, userID int64, cursor *BookmarkCursor, limit int) ([]BookmarkedPost, bool, error)
+	}
+
+	ReadingLists interface {
+		Create(context.Context, *ReadingList) error
+		GetByID(context.Context, int64) (*ReadingList, error)
+		GetByUserID(context.Context, int64) ([]ReadingList, error)
+		Update(context.Context, *ReadingList) error
+		Delete(context.Context, int64) error
+		AddPost(ctx context.Context, listID, postID int64) (int, error)
+		RemovePost(ctx context.Context, listID, postID int64) error
+		Reorder(ctx context.Context, listID int64, postIDs []int64) error
+		GetPosts(ctx context.Context, listID int64, after int, limit int) ([]ReadingListPost, bool, error)
+	}
+
+	PostViews interface {
+		AddDaily(context.Context, map[PostViewKey]int64) error
+		GetAuthorAnalytics(ctx context.Context, userID int64, from, to time.Time) ([]PostAnalytics, error)
+	}
+
+	Media interface {
+		Create(context.Context, *Media) error
+		GetByID(context.Context, int64) (*Media, error)
+	}
+
+	Pins interface {
+		Pin(context.Context, *PostPin) error
+		Unpin(ctx context.Context, postID int64) error
+		Feature(context.Context, *PostFeature) error
+		Unfeature(ctx context.Context, postID int64) error
+		GetFeatured(ctx context.Context, limit int) ([]FeaturedPost, error)
+	}
+
+	BulkOperations interface {
+		Count(ctx context.Context, target string, filter BulkFilter) (int64, int64, error)
+		Create(context.Context, *BulkOperation) error
+		Run(context.Context, *BulkOperation) error
+		ProcessBatch(ctx context.Context, id int64, limit int) (*BulkOperation, int64, error)
+		GetNextUnfinished(context.Context) (int64, error)
+		Fail(ctx context.Context, id int64, message string) error
+		Resume(ctx context.Context, id int64) error
+		GetByID(context.Context, int64) (*BulkOperation, error)
+		Get(ctx context.Context, page, limit int) ([]BulkOperation, int64, error)
+		GetItems(ctx context.Context, id int64, page, limit int) ([]BulkOperationItem, int64, error)
+	}
+
+	Translations interface {
+		Upsert(context.Context, *Translation) (bool, error)
+		GetByPostID(ctx context.Context, postID int64) ([]Translation, error)
+		GetForPosts(ctx context.Context, postIDs []int64, locale string) (map[int64]Translation, error)
+		GetPostIDBySlug(ctx context.Context, locale, slug string) (int64, error)
+		Delete(ctx context.Context, postID int64, locale string) error
+	}
+
+	PreviewTokens interface {
+		Create(ctx context.Context, token *PreviewToken, hashToken string, exp time.Duration) error
+		GetByPostID(ctx context.Context, postID int64) ([]PreviewToken, error)
+		GetPostID(ctx context.Context, hashToken string) (int64, error)
+		Revoke(ctx context.Context, postID, tokenID int64) error
+		DeleteExpired(ctx context.Context) (int64, error)
+	}
 }
 
 func NewStorage(db *sql.DB) Storage {
 	return Storage{
-		Posts:         &PostStore{db},
-		Users:         &UserStore{db},
-		Comments:      &CommentStore{db},
-		Categories:    &CategoryStore{db},
-		PostLikes:     &PostLikeStore{db},
-		Notifications: &NotificationStore{db},
+		Posts:          &PostStore{db},
+		Users:          &UserStore{db},
+		PostDrafts:     &PostDraftStore{db},
+		Comments:       &CommentStore{db},
+		Categories:     &CategoryStore{db},
+		PostLikes:      &PostLikeStore{db},
+		CommentLikes:   &CommentLikeStore{db},
+		Notifications:  &NotificationStore{db},
+		ContentVersion: &ContentVersionStore{db},
+		Follows:        &FollowStore{db},
+		PreviewTokens:  &PreviewTokenStore{db},
+		Media:          &MediaStore{db},
+		Series:         &SeriesStore{db},
+		CoAuthors:      &CoAuthorStore{db},
+		Reviews:        &ReviewStore{db},
+		Bookmarks:      &BookmarkStore{db},
+		ReadingLists:   &ReadingListStore{db},
+		PostViews:      &PostViewStore{db},
+		Translations:   &TranslationStore{db},
+		Pins:           &PinStore{db},
+		BulkOperations: &BulkOperationStore{db},
 	}
 }
 
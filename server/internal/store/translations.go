@@ -0,0 +1,195 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// Translation is a post rewritten in another locale than the one it was
+// written in. Slugs are unique within a locale.
+type Translation struct {
+	PostID       int64  `json:"post_id"`
+	Locale       string `json:"locale"`
+	Title        string `json:"title"`
+	Introduction string `json:"introduction"`
+	Content      string `json:"content"`
+	Slug         string `json:"slug"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+// Alternate points to a language version of a post, like an hreflang link
+type Alternate struct {
+	Locale string `json:"hreflang"`
+	Title  string `json:"title"`
+	Slug   string `json:"slug,omitempty"`
+	URL    string `json:"url"`
+	// Original marks the language the post was written in
+	Original bool `json:"original,omitempty"`
+}
+
+type TranslationStore struct {
+	db *sql.DB
+}
+
+// Upsert creates the translation of a post in a locale or replaces it.
+// created reports whether it did not exist yet. A slug already used in the
+// locale returns ErrUniqueViolation.
+func (s *TranslationStore) Upsert(ctx context.Context, translation *Translation) (bool, error) {
+	query := `
+		INSERT INTO post_translations (post_id, locale, title, introduction, content, slug)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (post_id, locale) DO UPDATE
+		SET title = EXCLUDED.title, introduction = EXCLUDED.introduction, content = EXCLUDED.content,
+			slug = EXCLUDED.slug, updated_at = NOW()
+		RETURNING created_at, updated_at, (xmax = 0) AS created
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var created bool
+	err := s.db.QueryRowContext(
+		ctx,
+		query,
+		translation.PostID,
+		translation.Locale,
+		translation.Title,
+		translation.Introduction,
+		translation.Content,
+		translation.Slug,
+	).Scan(&translation.CreatedAt, &translation.UpdatedAt, &created)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return false, ErrUniqueViolation
+		}
+		return false, err
+	}
+
+	return created, nil
+}
+
+// GetByPostID returns every translation of a post ordered by locale
+func (s *TranslationStore) GetByPostID(ctx context.Context, postID int64) ([]Translation, error) {
+	query := `
+		SELECT post_id, locale, title, introduction, content, slug, created_at, updated_at
+		FROM post_translations
+		WHERE post_id = $1
+		ORDER BY locale
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := []Translation{}
+	for rows.Next() {
+		var t Translation
+		err := rows.Scan(
+			&t.PostID,
+			&t.Locale,
+			&t.Title,
+			&t.Introduction,
+			&t.Content,
+			&t.Slug,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		translations = append(translations, t)
+	}
+
+	return translations, rows.Err()
+}
+
+// GetForPosts returns the translations in a locale of the given posts, keyed
+// by post ID. Only the fields listed in a feed are loaded.
+func (s *TranslationStore) GetForPosts(ctx context.Context, postIDs []int64, locale string) (map[int64]Translation, error) {
+	translations := map[int64]Translation{}
+	if len(postIDs) == 0 {
+		return translations, nil
+	}
+
+	query := `
+		SELECT post_id, locale, title, introduction, slug
+		FROM post_translations
+		WHERE post_id = ANY($1) AND locale = $2
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(postIDs), locale)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t Translation
+		if err := rows.Scan(&t.PostID, &t.Locale, &t.Title, &t.Introduction, &t.Slug); err != nil {
+			return nil, err
+		}
+
+		translations[t.PostID] = t
+	}
+
+	return translations, rows.Err()
+}
+
+// GetPostIDBySlug returns the post translated to locale under slug
+func (s *TranslationStore) GetPostIDBySlug(ctx context.Context, locale, slug string) (int64, error) {
+	query := `
+		SELECT pt.post_id
+		FROM post_translations pt
+		JOIN posts p ON p.id = pt.post_id
+		WHERE pt.locale = $1 AND pt.slug = $2 AND p.deleted_at IS NULL
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var postID int64
+	err := s.db.QueryRowContext(ctx, query, locale, slug).Scan(&postID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return 0, ErrNotFound
+		default:
+			return 0, err
+		}
+	}
+
+	return postID, nil
+}
+
+func (s *TranslationStore) Delete(ctx context.Context, postID int64, locale string) error {
+	query := `DELETE FROM post_translations WHERE post_id = $1 AND locale = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, postID, locale)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type TrashedPost struct {
+	ID         int64  `json:"id"`
+	Title      string `json:"title"`
+	Status     string `json:"status"`
+	UserID     int64  `json:"user_id"`
+	AuthorName string `json:"author_name"`
+	Category   string `json:"category"`
+	CreatedAt  string `json:"created_at"`
+	DeletedAt  string `json:"deleted_at"`
+	PurgeAt    string `json:"purge_at"`
+}
+
+const trashSelectQuery = `
+	SELECT p.id, p.title, p.status, p.user_id, COALESCE(u.name, ''), COALESCE(c.name, ''),
+		p.created_at, p.deleted_at
+	FROM posts p
+	LEFT JOIN users u ON u.id = p.user_id
+	LEFT JOIN categories c ON c.id = p.category_id
+`
+
+// GetTrash lists trashed posts, most recently deleted first. A zero userID
+// lists the trash of every author.
+func (s *PostStore) GetTrash(ctx context.Context, userID int64, page, limit int) ([]TrashedPost, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var total int64
+	countQuery := `
+		SELECT COUNT(*) FROM posts
+		WHERE deleted_at IS NOT NULL AND ($1 = 0 OR user_id = $1)
+	`
+	if err := s.db.QueryRowContext(ctx, countQuery, userID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := trashSelectQuery + `
+		WHERE p.deleted_at IS NOT NULL AND ($1 = 0 OR p.user_id = $1)
+		ORDER BY p.deleted_at DESC, p.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, userID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	posts := []TrashedPost{}
+	for rows.Next() {
+		var p TrashedPost
+		if err := rows.Scan(&p.ID, &p.Title, &p.Status, &p.UserID, &p.AuthorName, &p.Category, &p.CreatedAt, &p.DeletedAt); err != nil {
+			return nil, 0, err
+		}
+
+		posts = append(posts, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return posts, total, nil
+}
+
+func (s *PostStore) GetTrashedByID(ctx context.Context, id int64) (*TrashedPost, error) {
+	query := trashSelectQuery + `
+		WHERE p.id = $1 AND p.deleted_at IS NOT NULL
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var p TrashedPost
+	err := s.db.QueryRowContext(ctx, query, id).Scan(
+		&p.ID, &p.Title, &p.Status, &p.UserID, &p.AuthorName, &p.Category, &p.CreatedAt, &p.DeletedAt,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &p, nil
+}
+
+// Restore takes a post out of the trash
+func (s *PostStore) Restore(ctx context.Context, id int64) error {
+	query := `
+		UPDATE posts SET deleted_at = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+	return s.execTrashed(ctx, query, id)
+}
+
+// Purge permanently deletes a trashed post, along with its comments and likes
+func (s *PostStore) Purge(ctx context.Context, id int64) error {
+	query := `
+		DELETE FROM posts
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+	return s.execTrashed(ctx, query, id)
+}
+
+// PurgeDeletedBefore permanently deletes every post trashed before t
+func (s *PostStore) PurgeDeletedBefore(ctx context.Context, t time.Time) (int64, error) {
+	query := `
+		DELETE FROM posts
+		WHERE deleted_at IS NOT NULL AND deleted_at < $1
+	`
+	ctx, cancel := context.WithTimeout(ctx, BatchQueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, t)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (s *PostStore) execTrashed(ctx context.Context, query string, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -23,10 +23,12 @@ type User struct {
 	Name           string   `json:"name"`
 	Password       password `json:"-"`
 	ProfilePicture string   `json:"profile_picture"`
-	CreatedAt      string   `json:"created_at"`
-	Role           string   `json:"role"`
-	Bio            string   `json:"bio"`
-	IsActive       bool     `json:"is_active"`
+	// ProfilePictureMediaID references the uploaded media ProfilePicture points to
+	ProfilePictureMediaID *int64 `json:"profile_picture_media_id"`
+	CreatedAt             string `json:"created_at"`
+	Role                  string `json:"role"`
+	Bio                   string `json:"bio"`
+	IsActive              bool   `json:"is_active"`
 }
 
 type password struct {
@@ -113,7 +115,7 @@ func (s *UserStore) CreateAndInvite(ctx context.Context, user *User, token strin
 
 func (s *UserStore) GetByID(ctx context.Context, userID int64) (*User, error) {
 	query := `
-		SELECT id, username, password, email, name, profile_picture, created_at, role
+		SELECT id, username, password, email, name, profile_picture, profile_picture_media_id, created_at, role
 		FROM users
 		WHERE id = $1
 	`
@@ -133,6 +135,7 @@ func (s *UserStore) GetByID(ctx context.Context, userID int64) (*User, error) {
 		&user.Email,
 		&user.Name,
 		&user.ProfilePicture,
+		&user.ProfilePictureMediaID,
 		&user.CreatedAt,
 		&user.Role,
 	); err != nil {
@@ -147,6 +150,37 @@ func (s *UserStore) GetByID(ctx context.Context, userID int64) (*User, error) {
 	return user, nil
 }
 
+func (s *UserStore) GetByUsername(ctx context.Context, username string) (*User, error) {
+	query := `
+		SELECT id, username, name, email, profile_picture, created_at, role FROM users
+		WHERE username = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	user := &User{}
+	err := s.db.QueryRowContext(ctx, query, username).Scan(
+		&user.ID,
+		&user.Username,
+		&user.Name,
+		&user.Email,
+		&user.ProfilePicture,
+		&user.CreatedAt,
+		&user.Role,
+	)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
 func (s *UserStore) GetByEmail(ctx context.Context, email string) (*User, error) {
 	query := `
 		SELECT id, username, name, email, password, profile_picture, created_at, role FROM users
@@ -216,7 +250,7 @@ func (s *UserStore) Delete(ctx context.Context, userID int64) error {
 func (s *UserStore) Update(ctx context.Context, user *User) (int64, error) {
 	query := `
         UPDATE users 
-        SET username = $1, name = $2, email = $3, bio = $4, profile_picture = $5 
+        SET username = $1, name = $2, email = $3, bio = $4, profile_picture = $5, profile_picture_media_id = $7
         WHERE id = $6
     `
 
@@ -228,6 +262,7 @@ func (s *UserStore) Update(ctx context.Context, user *User) (int64, error) {
 		user.Username, user.Name,
 		user.Email, user.Bio,
 		user.ProfilePicture, user.ID,
+		user.ProfilePictureMediaID,
 	)
 
 	if err != nil {
@@ -0,0 +1,149 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PostViewKey identifies the views of a post on a day (UTC, YYYY-MM-DD)
+type PostViewKey struct {
+	PostID int64
+	Day    string
+}
+
+type DailyStats struct {
+	Date     string `json:"date"`
+	Views    int64  `json:"views"`
+	Likes    int64  `json:"likes"`
+	Comments int64  `json:"comments"`
+}
+
+type PostAnalytics struct {
+	PostID   int64        `json:"post_id"`
+	Title    string       `json:"title"`
+	Views    int64        `json:"views"`
+	Likes    int64        `json:"likes"`
+	Comments int64        `json:"comments"`
+	Daily    []DailyStats `json:"daily"`
+}
+
+type PostViewStore struct {
+	db *sql.DB
+}
+
+// AddDaily adds buffered view counts to the daily totals in one statement
+func (s *PostViewStore) AddDaily(ctx context.Context, counts map[PostViewKey]int64) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]int64, 0, len(counts))
+	days := make([]string, 0, len(counts))
+	views := make([]int64, 0, len(counts))
+	for key, n := range counts {
+		postIDs = append(postIDs, key.PostID)
+		days = append(days, key.Day)
+		views = append(views, n)
+	}
+
+	// posts deleted since the views were buffered are skipped by the join
+	query := `
+		INSERT INTO post_views_daily (post_id, day, views)
+		SELECT v.post_id, v.day::date, v.views
+		FROM unnest($1::bigint[], $2::text[], $3::bigint[]) AS v(post_id, day, views)
+		JOIN posts p ON p.id = v.post_id
+		ON CONFLICT (post_id, day) DO UPDATE SET views = post_views_daily.views + EXCLUDED.views
+	`
+	ctx, cancel := context.WithTimeout(ctx, BatchQueryTimeoutDuration)
+	defer cancel()
+
+	_, err := s.db.ExecContext(ctx, query, pq.Array(postIDs), pq.Array(days), pq.Array(views))
+	return err
+}
+
+// GetAuthorAnalytics returns the daily views, likes and comments of the posts
+// of an author between from and to, both included. Days are UTC. Posts with
+// no activity in the range are left out, the others get one entry per day.
+func (s *PostViewStore) GetAuthorAnalytics(ctx context.Context, userID int64, from, to time.Time) ([]PostAnalytics, error) {
+	query := `
+		WITH days AS (
+			SELECT generate_series($2::date, $3::date, INTERVAL '1 day')::date AS day
+		), author_posts AS (
+			SELECT id, title FROM posts WHERE user_id = $1 AND deleted_at IS NULL
+		), range AS (
+			SELECT $2::date::timestamp AT TIME ZONE 'UTC' AS start_at,
+				($3::date + 1)::timestamp AT TIME ZONE 'UTC' AS end_at
+		), views AS (
+			SELECT v.post_id, v.day, v.views
+			FROM post_views_daily v
+			JOIN author_posts ap ON ap.id = v.post_id
+			WHERE v.day BETWEEN $2::date AND $3::date
+		), likes AS (
+			SELECT pl.post_id, (pl.created_at AT TIME ZONE 'UTC')::date AS day, COUNT(*) AS likes
+			FROM post_likes pl
+			JOIN author_posts ap ON ap.id = pl.post_id
+			CROSS JOIN range
+			WHERE pl.created_at >= range.start_at AND pl.created_at < range.end_at
+			GROUP BY 1, 2
+		), comment_counts AS (
+			SELECT c.post_id, (c.created_at AT TIME ZONE 'UTC')::date AS day, COUNT(*) AS comments
+			FROM comments c
+			JOIN author_posts ap ON ap.id = c.post_id
+			CROSS JOIN range
+			WHERE c.created_at >= range.start_at AND c.created_at < range.end_at
+			GROUP BY 1, 2
+		), active AS (
+			SELECT post_id FROM views
+			UNION SELECT post_id FROM likes
+			UNION SELECT post_id FROM comment_counts
+		)
+		SELECT ap.id, ap.title, d.day,
+			COALESCE(v.views, 0), COALESCE(l.likes, 0), COALESCE(cc.comments, 0)
+		FROM author_posts ap
+		JOIN active a ON a.post_id = ap.id
+		CROSS JOIN days d
+		LEFT JOIN views v ON v.post_id = ap.id AND v.day = d.day
+		LEFT JOIN likes l ON l.post_id = ap.id AND l.day = d.day
+		LEFT JOIN comment_counts cc ON cc.post_id = ap.id AND cc.day = d.day
+		ORDER BY ap.id DESC, d.day
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, userID, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	posts := []PostAnalytics{}
+	for rows.Next() {
+		var postID int64
+		var title string
+		var day time.Time
+		var stats DailyStats
+		if err := rows.Scan(&postID, &title, &day, &stats.Views, &stats.Likes, &stats.Comments); err != nil {
+			return nil, err
+		}
+		stats.Date = day.Format(time.DateOnly)
+
+		if len(posts) == 0 || posts[len(posts)-1].PostID != postID {
+			posts = append(posts, PostAnalytics{PostID: postID, Title: title, Daily: []DailyStats{}})
+		}
+
+		post := &posts[len(posts)-1]
+		post.Views += stats.Views
+		post.Likes += stats.Likes
+		post.Comments += stats.Comments
+		post.Daily = append(post.Daily, stats)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}